@@ -0,0 +1,57 @@
+package tilelayout
+
+// Alignment of the tiles on the cross axis of the layout, like align-items in CSS flexbox.
+// Tiles have no size of their own content, so a tile takes the whole cross axis unless it is
+// limited by its max or fixed size; the alignment places such a tile in the leftover space.
+type Align int
+
+const (
+	// For a tile: use the Align of the layout. For a layout: same as AlignStretch.
+	AlignAuto Align = iota
+	AlignStart
+	AlignCenter
+	AlignEnd
+	AlignStretch
+)
+
+// Distribution of the leftover space on the main axis of the layout, like justify-content
+// in CSS flexbox. There is leftover space when the tiles are limited by their max or fixed sizes.
+type Justify int
+
+const (
+	JustifyStart Justify = iota
+	JustifyCenter
+	JustifyEnd
+	// The leftover space goes between the tiles.
+	JustifySpaceBetween
+	// The leftover space goes around each tile, so the space at the edges is half the space between.
+	JustifySpaceAround
+)
+
+// Returns the offset of a tile with the alignment in the leftover cross axis space.
+func (a Align) offset(leftover int) int {
+	switch a {
+	case AlignCenter:
+		return leftover / 2
+	case AlignEnd:
+		return leftover
+	}
+	return 0
+}
+
+// Returns the offset of the tile at the index of the count of tiles, caused by the leftover main axis space.
+func (j Justify) offset(leftover, index, count int) int {
+	switch j {
+	case JustifyCenter:
+		return leftover / 2
+	case JustifyEnd:
+		return leftover
+	case JustifySpaceBetween:
+		if count > 1 {
+			return leftover * index / (count - 1)
+		}
+	case JustifySpaceAround:
+		return leftover * (2*index + 1) / (2 * count)
+	}
+	return 0
+}
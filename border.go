@@ -0,0 +1,299 @@
+package tilelayout
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// Borders drawn by a layout around and between its tiles, see TileLayout.Borders.
+// Adjacent tiles share a single line, and the lines of the nested layouts with Borders
+// join the lines of their parents with the junction glyphs (┬ ┴ ├ ┤ ┼).
+type Borders struct {
+	// The glyphs of the lines, like lipgloss.NormalBorder() or lipgloss.RoundedBorder().
+	Border lipgloss.Border
+	Style  lipgloss.Style
+	// Draw the titles of the tiles into their top edges, see Titled.
+	Titles     bool
+	TitleStyle lipgloss.Style
+}
+
+// Creates new Borders with the normal glyphs and the titles.
+func NewBorders() *Borders {
+	return &Borders{
+		Border: lipgloss.NormalBorder(),
+		Titles: true,
+	}
+}
+
+// Tiles implementing Titled provide the title drawn into their top edge.
+// The name of the tile is used for the other tiles.
+type Titled interface {
+	Title() string
+}
+
+// The directions of the line in a cell, from which the glyph is chosen.
+const (
+	lineUp = 1 << iota
+	lineRight
+	lineDown
+	lineLeft
+	// orientation of the line, for the cells with no neighbours
+	lineHorizontal
+	lineVertical
+)
+
+type point struct {
+	x, y int
+}
+
+// A cell of the borders: a line with its directions, or a part of a title.
+type borderCell struct {
+	lines int
+	title bool
+	// the text of a title cell, empty for the second cell of a wide character
+	text string
+}
+
+// Returns the layout drawing the borders of this layout: the outermost one
+// of the chain of the layouts with Borders containing it.
+func (tl *TileLayout) borderRoot() *TileLayout {
+	root := tl
+	for {
+		parent, ok := root.GetParent().(*TileLayout)
+		if !ok || parent.Borders == nil {
+			return root
+		}
+		root = parent
+	}
+}
+
+// Returns true if the layout draws the frame around its tiles: it has Borders and
+// its parent does not, otherwise the lines of the parent are around it already.
+func (tl *TileLayout) framed() bool {
+	return tl.Borders != nil && tl.borderRoot() == tl
+}
+
+// The space between the edges of the layout and its tiles: the padding and the frame.
+func (tl *TileLayout) inset() Spacing {
+	s := tl.Padding
+	if tl.framed() {
+		s.Top, s.Right, s.Bottom, s.Left = s.Top+1, s.Right+1, s.Bottom+1, s.Left+1
+	}
+	return s
+}
+
+// The number of cells between the tiles. With Borders there is at least one, for the line.
+func (tl *TileLayout) gap() int {
+	if tl.Borders != nil {
+		return max(tl.Gap, 1)
+	}
+	return tl.Gap
+}
+
+// Draw the lines and the titles of the layout and all nested layouts sharing its borders.
+// The frame goes along the edges of the layout and the lines between the tiles go through
+// the middle of the gaps (see separators), joined with the lines they touch.
+func (tl *TileLayout) drawBorders() map[point]borderCell {
+	cells := make(map[point]borderCell)
+	var ends [][2]point
+	line := func(from, to point) {
+		horizontal := from.y == to.y
+		for p := from; ; {
+			cell := cells[p]
+			if horizontal {
+				cell.lines |= lineHorizontal
+				if p.x > from.x {
+					cell.lines |= lineLeft
+				}
+				if p.x < to.x {
+					cell.lines |= lineRight
+				}
+			} else {
+				cell.lines |= lineVertical
+				if p.y > from.y {
+					cell.lines |= lineUp
+				}
+				if p.y < to.y {
+					cell.lines |= lineDown
+				}
+			}
+			cells[p] = cell
+			if p == to {
+				break
+			}
+			if horizontal {
+				p.x++
+			} else {
+				p.y++
+			}
+		}
+		ends = append(ends, [2]point{from, to})
+	}
+	r := tl.Rect
+	if !r.Empty() {
+		right, bottom := r.X+r.Width-1, r.Y+r.Height-1
+		line(point{r.X, r.Y}, point{right, r.Y})
+		line(point{r.X, bottom}, point{right, bottom})
+		line(point{r.X, r.Y}, point{r.X, bottom})
+		line(point{right, r.Y}, point{right, bottom})
+	}
+	var layouts []*TileLayout
+	for layout := range tl.Layouts() {
+		if layout.Borders != nil && layout.borderRoot() == tl {
+			layouts = append(layouts, layout)
+		}
+	}
+	for _, layout := range layouts {
+		content := layout.Rect.Inset(layout.inset())
+		if content.Empty() {
+			continue
+		}
+		for _, pos := range layout.separators {
+			if layout.Direction == Horizontal {
+				line(point{pos, content.Y}, point{pos, content.Y + content.Height - 1})
+			} else {
+				line(point{content.X, pos}, point{content.X + content.Width - 1, pos})
+			}
+		}
+	}
+	// join the ends of the lines with the lines they touch
+	join := func(p, next point, dir, back int) {
+		if neighbour, ok := cells[next]; ok {
+			cell := cells[p]
+			cell.lines |= dir
+			cells[p] = cell
+			neighbour.lines |= back
+			cells[next] = neighbour
+		}
+	}
+	for _, end := range ends {
+		from, to := end[0], end[1]
+		if from.y == to.y {
+			join(from, point{from.x - 1, from.y}, lineLeft, lineRight)
+			join(to, point{to.x + 1, to.y}, lineRight, lineLeft)
+		} else {
+			join(from, point{from.x, from.y - 1}, lineUp, lineDown)
+			join(to, point{to.x, to.y + 1}, lineDown, lineUp)
+		}
+	}
+	if tl.Borders.Titles {
+		for _, layout := range layouts {
+			for _, tile := range layout.Tiles {
+				if _, nested := tile.(*TileLayout); tile != nil && !nested && !tile.GetRect().Empty() {
+					drawTitle(cells, tile)
+				}
+			}
+		}
+	}
+	return cells
+}
+
+// Draw the title of the tile into the line above it, between the corners.
+// The title is cut where the line ends.
+func drawTitle(cells map[point]borderCell, tile Tile) {
+	title := tile.GetName()
+	if titled, ok := tile.(Titled); ok {
+		title = titled.Title()
+	}
+	r := tile.GetRect()
+	x, end := r.X, r.X+r.Width
+	for _, ch := range ansi.Strip(title) {
+		width := ansi.StringWidth(string(ch))
+		if width == 0 {
+			continue
+		}
+		if x+width > end {
+			return
+		}
+		for i := range width {
+			p := point{x + i, r.Y - 1}
+			if cells[p].lines&lineHorizontal == 0 {
+				return
+			}
+		}
+		for i := range width {
+			text := ""
+			if i == 0 {
+				text = string(ch)
+			}
+			cells[point{x + i, r.Y - 1}] = borderCell{title: true, text: text}
+		}
+		x += width
+	}
+}
+
+// Returns the glyph of the line with the directions.
+func glyph(b lipgloss.Border, lines int) string {
+	switch lines & (lineUp | lineRight | lineDown | lineLeft) {
+	case lineRight | lineDown:
+		return b.TopLeft
+	case lineLeft | lineDown:
+		return b.TopRight
+	case lineRight | lineUp:
+		return b.BottomLeft
+	case lineLeft | lineUp:
+		return b.BottomRight
+	case lineUp | lineDown | lineRight:
+		return b.MiddleLeft
+	case lineUp | lineDown | lineLeft:
+		return b.MiddleRight
+	case lineLeft | lineRight | lineDown:
+		return b.MiddleTop
+	case lineLeft | lineRight | lineUp:
+		return b.MiddleBottom
+	case lineUp | lineRight | lineDown | lineLeft:
+		return b.Middle
+	case lineUp, lineDown, lineUp | lineDown:
+		return b.Left
+	case lineLeft, lineRight, lineLeft | lineRight:
+		return b.Top
+	}
+	if lines&lineVertical != 0 {
+		return b.Left
+	}
+	return b.Top
+}
+
+// Returns the number of cells from the position in the layout (relative to the layout),
+// with the lines and the titles of the borders, and the FillStyle elsewhere.
+func (tl *TileLayout) fillAt(x, y, cells int) string {
+	if cells <= 0 {
+		return ""
+	}
+	root := tl.borderRoot()
+	if tl.Borders == nil || root.borders == nil {
+		return tl.fill(cells)
+	}
+	var sb, run strings.Builder
+	kind := 0
+	flush := func() {
+		switch kind {
+		case 0:
+			sb.WriteString(tl.FillStyle.Render(run.String()))
+		case 1:
+			sb.WriteString(root.Borders.Style.Render(run.String()))
+		case 2:
+			sb.WriteString(root.Borders.TitleStyle.Render(run.String()))
+		}
+		run.Reset()
+	}
+	for i := range cells {
+		cell, ok := root.borders[point{tl.Rect.X + x + i, tl.Rect.Y + y}]
+		next, text := 0, " "
+		switch {
+		case ok && cell.title:
+			next, text = 2, cell.text
+		case ok:
+			next, text = 1, glyph(root.Borders.Border, cell.lines)
+		}
+		if next != kind && run.Len() > 0 {
+			flush()
+		}
+		kind = next
+		run.WriteString(text)
+	}
+	flush()
+	return sb.String()
+}
@@ -0,0 +1,111 @@
+package tilelayout
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestGlyph(t *testing.T) {
+	b := lipgloss.NormalBorder()
+	tests := []struct {
+		lines int
+		want  string
+	}{
+		{lineRight | lineDown, "┌"},
+		{lineLeft | lineDown, "┐"},
+		{lineRight | lineUp, "└"},
+		{lineLeft | lineUp, "┘"},
+		{lineUp | lineDown | lineRight, "├"},
+		{lineUp | lineDown | lineLeft, "┤"},
+		{lineLeft | lineRight | lineDown, "┬"},
+		{lineLeft | lineRight | lineUp, "┴"},
+		{lineUp | lineRight | lineDown | lineLeft, "┼"},
+		{lineLeft | lineRight, "─"},
+		{lineRight, "─"},
+		{lineUp | lineDown, "│"},
+		{lineDown, "│"},
+		{lineHorizontal, "─"},
+		{lineVertical, "│"},
+	}
+	for _, tt := range tests {
+		if got := glyph(b, tt.lines); got != tt.want {
+			t.Errorf("glyph(%b) = %q; want %q", tt.lines, got, tt.want)
+		}
+	}
+}
+
+// Render the layout in the window size, without the styles.
+func render(root *TileLayout, width, height int) string {
+	root.Update(tea.WindowSizeMsg{Width: width, Height: height})
+	return ansi.Strip(root.View())
+}
+
+func TestBordersJunctions(t *testing.T) {
+	root := NewRoot(Horizontal)
+	root.Borders = NewBorders()
+	right := NewTileLayout("right", Vertical, Size{Weight: 0.5})
+	right.Borders = NewBorders()
+	bottom := NewTileLayout("bottom", Horizontal, Size{Weight: 0.5})
+	bottom.Borders = NewBorders()
+	root.Add(newTestTile("a", Size{Weight: 0.5}))
+	root.Add(right)
+	right.Add(newTestTile("b", Size{Weight: 0.5}))
+	right.Add(bottom)
+	bottom.Add(newTestTile("c", Size{Weight: 0.5}))
+	bottom.Add(newTestTile("d", Size{Weight: 0.5}))
+
+	want := strings.Join([]string{
+		"┌a────────┬b────────┐",
+		"│         │         │",
+		"│         │         │",
+		"│         ├c───┬d───┤",
+		"│         │    │    │",
+		"│         │    │    │",
+		"└─────────┴────┴────┘",
+	}, "\n")
+	if got := render(root, 21, 7); got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestBordersNotJoinedAcrossPadding(t *testing.T) {
+	root := NewRoot(Horizontal)
+	root.Borders = NewBorders()
+	root.Borders.Titles = false
+	inner := NewTileLayout("inner", Vertical, Size{Weight: 1})
+	inner.Borders = NewBorders()
+	inner.Padding = NewSpacing(0, 1)
+	root.Add(inner)
+	inner.Add(newTestTile("a", Size{Weight: 0.5}))
+	inner.Add(newTestTile("b", Size{Weight: 0.5}))
+
+	want := strings.Join([]string{
+		"┌───────┐",
+		"│       │",
+		"│ ───── │",
+		"│       │",
+		"└───────┘",
+	}, "\n")
+	if got := render(root, 9, 5); got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestBordersTitleCut(t *testing.T) {
+	root := NewRoot(Horizontal)
+	root.Borders = NewBorders()
+	root.Add(newTestTile("日本語abc", Size{Weight: 1}))
+
+	want := strings.Join([]string{
+		"┌日本語┐",
+		"│      │",
+		"└──────┘",
+	}, "\n")
+	if got := render(root, 8, 3); got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+}
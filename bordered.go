@@ -0,0 +1,267 @@
+package tilelayout
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// Options of a tile wrapped by Bordered.
+type BorderOptions struct {
+	// The glyphs of the frame, the normal border if empty.
+	Border lipgloss.Border
+	// Style of the frame, and of the frame of the focused tile.
+	Style        lipgloss.Style
+	FocusedStyle lipgloss.Style
+	// Drawn into the top edge on the left. The title of the tile (see Titled) or its name if empty.
+	Title string
+	// Drawn into the top edge on the right, like a counter or a state.
+	Badge string
+	// Drawn into the bottom edge on the left.
+	Footer string
+	// Style of the title, the badge and the footer.
+	TitleStyle lipgloss.Style
+}
+
+// Creates new BorderOptions with the rounded glyphs, highlighting the focused tile.
+func NewBorderOptions() BorderOptions {
+	return BorderOptions{
+		Border:       lipgloss.RoundedBorder(),
+		Style:        lipgloss.NewStyle().Foreground(lipgloss.Color("62")),
+		FocusedStyle: lipgloss.NewStyle().Foreground(lipgloss.Color("205")),
+	}
+}
+
+// A tile drawn in a frame, see Bordered. The frame is part of the tile for the layout:
+// the size of the BorderedTile includes the frame, while the wrapped tile gets the size,
+// the rect and the WindowSizeMsg of the inside of the frame and the mouse events relative to it.
+// The name, the ID and the parent are the ones of the wrapped tile, and the hooks
+// and the optional interfaces of the wrapped tile are called through the BorderedTile.
+type BorderedTile struct {
+	Tile
+	Options BorderOptions
+	rect    Rect
+	focused bool
+}
+
+// Wrap the tile in a frame with a title, an optional badge and footer, highlighted when
+// the tile is focused. The tile should be a leaf tile, not a layout, and is replaced
+// by the returned tile in the layout. The size constraints of the tile are for the inside
+// of the frame, the layout reserves the two cells of the frame on top of them.
+func Bordered(tile Tile, opts BorderOptions) *BorderedTile {
+	if opts.Border == (lipgloss.Border{}) {
+		opts.Border = lipgloss.NormalBorder()
+	}
+	return &BorderedTile{Tile: tile, Options: opts}
+}
+
+// The size of the frame with the tile inside: the sizes and the min/max/fixed
+// of the wrapped tile grow by the frame, unless they are not set.
+func (bt *BorderedTile) GetSize() Size {
+	s := bt.Tile.GetSize()
+	s.Width, s.Height = s.Width+2, s.Height+2
+	s.MinWidth, s.MinHeight = s.MinWidth+2, s.MinHeight+2
+	s.MaxWidth, s.MaxHeight = grow(s.MaxWidth), grow(s.MaxHeight)
+	s.FixedWidth, s.FixedHeight = grow(s.FixedWidth), grow(s.FixedHeight)
+	return s
+}
+
+// Set the size of the frame, the wrapped tile gets the size of its inside.
+// The constraints for the inside are set on the wrapped tile, see SetSizeMsg.
+func (bt *BorderedTile) SetSize(size Size) {
+	bt.Tile.SetSize(insideSize(size))
+}
+
+func (bt *BorderedTile) GetRect() Rect { return bt.rect }
+
+// Set the rect of the frame, the wrapped tile gets the rect of its inside.
+func (bt *BorderedTile) SetRect(rect Rect) {
+	bt.rect = rect
+	bt.Tile.SetRect(inside(rect))
+}
+
+// Track the focus and pass the message to the wrapped tile, with the WindowSizeMsg,
+// the sizes of the TileUpdatedMsg and the mouse events translated to its inside.
+func (bt *BorderedTile) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch m := msg.(type) {
+	case FocusMsg:
+		bt.focused = true
+	case BlurMsg:
+		bt.focused = false
+	case tea.WindowSizeMsg:
+		msg = tea.WindowSizeMsg{Width: shrink(m.Width), Height: shrink(m.Height)}
+	case TileUpdatedMsg:
+		m.OldSize, m.NewSize = insideSize(m.OldSize), insideSize(m.NewSize)
+		msg = m
+	case tea.MouseMsg:
+		m.X, m.Y = m.X-1, m.Y-1
+		msg = m
+	}
+	updated, cmd := bt.Tile.Update(msg)
+	bt.Tile = updated.(Tile)
+	return bt, cmd
+}
+
+// Render the view of the wrapped tile, clipped and padded to the inside, in the frame.
+func (bt *BorderedTile) View() string {
+	width, height := bt.rect.Width, bt.rect.Height
+	if width < 2 || height < 2 {
+		return ""
+	}
+	b := bt.Options.Border
+	style := bt.Options.Style
+	if bt.focused {
+		style = bt.Options.FocusedStyle
+	}
+	lines := strings.Split(bt.Tile.View(), "\n")
+	rows := make([]string, 0, height)
+	rows = append(rows, bt.edge(b.TopLeft, b.Top, b.TopRight, bt.Title(), bt.Options.Badge, style))
+	for y := range height - 2 {
+		line := ""
+		if y < len(lines) {
+			line = ansi.Truncate(lines[y], width-2, "")
+		}
+		line += strings.Repeat(" ", width-2-ansi.StringWidth(line))
+		rows = append(rows, style.Render(b.Left)+line+style.Render(b.Right))
+	}
+	rows = append(rows, bt.edge(b.BottomLeft, b.Bottom, b.BottomRight, bt.Options.Footer, "", style))
+	return strings.Join(rows, "\n")
+}
+
+// Render an edge of the frame with the text on the left and the badge on the right.
+// The text is cut to the edge, the badge is left out if there is no room for it.
+func (bt *BorderedTile) edge(left, line, right, text, badge string, style lipgloss.Style) string {
+	width := bt.rect.Width - 2
+	text = ansi.Truncate(text, width, "…")
+	if ansi.StringWidth(text)+ansi.StringWidth(badge) > width {
+		badge = ""
+	}
+	rest := width - ansi.StringWidth(text) - ansi.StringWidth(badge)
+	var sb strings.Builder
+	sb.WriteString(style.Render(left))
+	if text != "" {
+		sb.WriteString(bt.Options.TitleStyle.Render(text))
+	}
+	sb.WriteString(style.Render(strings.Repeat(line, rest)))
+	if badge != "" {
+		sb.WriteString(bt.Options.TitleStyle.Render(badge))
+	}
+	sb.WriteString(style.Render(right))
+	return sb.String()
+}
+
+// Returns the title drawn into the top edge: the Title of the options,
+// the title of the wrapped tile (see Titled) or its name.
+func (bt *BorderedTile) Title() string {
+	if bt.Options.Title != "" {
+		return bt.Options.Title
+	}
+	if titled, ok := bt.Tile.(Titled); ok {
+		return titled.Title()
+	}
+	return bt.Tile.GetName()
+}
+
+// Returns true if the wrapped tile has the class, see Classed.
+func (bt *BorderedTile) HasClass(class string) bool {
+	classed, ok := bt.Tile.(Classed)
+	return ok && classed.HasClass(class)
+}
+
+// Returns false if the wrapped tile opted out of the focus, see Focusable.
+func (bt *BorderedTile) Focusable() bool {
+	f, ok := bt.Tile.(Focusable)
+	return !ok || f.Focusable()
+}
+
+// Returns false if the wrapped tile declines the key, see KeyConsumer.
+func (bt *BorderedTile) ConsumesKey(msg tea.KeyMsg) bool {
+	consumer, ok := bt.Tile.(KeyConsumer)
+	return !ok || consumer.ConsumesKey(msg)
+}
+
+// Returns the message types declared by the wrapped tile, see MsgConsumer.
+func (bt *BorderedTile) ConsumedMsgs() []tea.Msg {
+	if consumer, ok := bt.Tile.(MsgConsumer); ok {
+		return consumer.ConsumedMsgs()
+	}
+	return nil
+}
+
+// Returns true if the wrapped tile observes all mouse events, see MouseObserver.
+func (bt *BorderedTile) ObservesAllMouse() bool {
+	observer, ok := bt.Tile.(MouseObserver)
+	return ok && observer.ObservesAllMouse()
+}
+
+func (bt *BorderedTile) Mount() tea.Cmd {
+	return mountHook(bt.Tile)
+}
+
+func (bt *BorderedTile) Unmount() {
+	unmountHook(bt.Tile)
+}
+
+// Call the Resizer of the wrapped tile with the insides of the rects.
+func (bt *BorderedTile) Resize(old, new Rect) tea.Cmd {
+	if r, ok := bt.Tile.(Resizer); ok {
+		return r.Resize(inside(old), inside(new))
+	}
+	return nil
+}
+
+func (bt *BorderedTile) VisibilityChanged(visible bool) tea.Cmd {
+	if v, ok := bt.Tile.(VisibilityAware); ok {
+		return v.VisibilityChanged(visible)
+	}
+	return nil
+}
+
+// Returns the tile wrapped in the tile, or the tile itself.
+func unwrap(tile Tile) Tile {
+	if bt, ok := tile.(*BorderedTile); ok {
+		return bt.Tile
+	}
+	return tile
+}
+
+// The rect inside the frame. An empty rect stays empty.
+func inside(r Rect) Rect {
+	if r.Empty() {
+		return r
+	}
+	return r.Inset(NewSpacing(1))
+}
+
+// The size with the frame, or 0 for a size that is not set.
+func grow(size int) int {
+	if size > 0 {
+		return size + 2
+	}
+	return 0
+}
+
+// The size without the frame.
+func shrink(size int) int {
+	return max(0, size-2)
+}
+
+// The size of the inside of the frame. The max and fixed sizes which are set
+// stay at least 1, as 0 means they are not set.
+func insideSize(s Size) Size {
+	s.Width, s.Height = shrink(s.Width), shrink(s.Height)
+	s.MinWidth, s.MinHeight = shrink(s.MinWidth), shrink(s.MinHeight)
+	s.MaxWidth, s.MaxHeight = shrinkSet(s.MaxWidth), shrinkSet(s.MaxHeight)
+	s.FixedWidth, s.FixedHeight = shrinkSet(s.FixedWidth), shrinkSet(s.FixedHeight)
+	return s
+}
+
+// The size without the frame, keeping a size that is set at least 1.
+func shrinkSet(size int) int {
+	if size > 0 {
+		return max(1, size-2)
+	}
+	return 0
+}
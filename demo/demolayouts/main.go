@@ -10,10 +10,24 @@ import (
 func main() {
 	// m := initialModelMinimal()
 	// m := initialModelWithConstraints()
-	m := NewDemoModel()
-	p := tea.NewProgram(m, tea.WithAltScreen())
+	m, err := NewDemoModel()
+	if err != nil {
+		fmt.Printf("Invalid layout: %v\n", err)
+		os.Exit(1)
+	}
+	for i := range m.layouts {
+		if err := m.layouts[i].Validate(); err != nil {
+			fmt.Printf("Invalid layout: %v\n", err)
+			os.Exit(1)
+		}
+	}
+	p := tea.NewProgram(m, tea.WithAltScreen(), tea.WithMouseCellMotion())
 
-	if _, err := p.Run(); err != nil {
+	final, err := p.Run()
+	if err == nil {
+		err = final.(DemoModel).Err()
+	}
+	if err != nil {
 		fmt.Printf("Error: %v\n", err)
 		os.Exit(1)
 	}
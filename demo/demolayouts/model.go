@@ -6,34 +6,72 @@ import (
 )
 
 type DemoModel struct {
-	layouts   []tl.TileLayout
+	layouts   []*tl.TileLayout
 	selected  int
 	statusBar tl.Tile
+	// the error the demo quit with
+	err error
 }
 
-func NewDemoModel() DemoModel {
-	min := initialModelMinimal()
-	weights := initialModelWeightsOnly()
-	constraints := initialModelWithConstraints()
-	many := initialModelManyLayouts()
+// Message to quit the demo with the error.
+type errMsg struct {
+	err error
+}
+
+func NewDemoModel() (DemoModel, error) {
+	var layouts []*tl.TileLayout
+	for _, build := range []func() (*tl.TileLayout, error){
+		initialModelMinimal,
+		initialModelWeightsOnly,
+		initialModelWithConstraints,
+		initialModelManyLayouts,
+		initialModelBorders,
+	} {
+		layout, err := build()
+		if err != nil {
+			return DemoModel{}, err
+		}
+		layouts = append(layouts, layout)
+	}
+	for _, layout := range layouts {
+		// the boxes emit "close" when 'x' is pressed
+		layout.OnEvent("close", func(e *tl.Event) tea.Cmd {
+			if _, err := layout.Remove(tl.Path(e.Source)); err != nil {
+				return func() tea.Msg { return errMsg{err: err} }
+			}
+			return layout.FocusNext()
+		})
+	}
 	return DemoModel{
-		layouts:  []tl.TileLayout{min, weights, constraints, many},
+		layouts:  layouts,
 		selected: 0,
-	}
+	}, nil
+}
+
+// Returns the error the demo quit with, or nil.
+func (d DemoModel) Err() error {
+	return d.err
 }
 
 func (d DemoModel) Init() tea.Cmd {
-	return d.layouts[d.selected].Init()
+	var cmds []tea.Cmd
+	for _, layout := range d.layouts {
+		cmds = append(cmds, layout.Init())
+	}
+	return tea.Batch(cmds...)
 }
 
 func (d DemoModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmds []tea.Cmd
 	switch msg := msg.(type) {
+	case errMsg:
+		d.err = msg.err
+		return d, tea.Quit
 	case tea.KeyMsg:
 		switch msg.String() {
 		case "ctrl+c", "q":
 			return d, tea.Quit
-		case "tab":
+		case "n":
 			return d.updateSelection()
 		}
 
@@ -1,77 +1,116 @@
 package main
 
 import (
+	"errors"
+
+	"github.com/charmbracelet/lipgloss"
 	tl "github.com/mko88/bubbletea-tilelayout"
 	"github.com/mko88/bubbletea-tilelayout/demo/tiles"
 )
 
-func initialModelMinimal() tl.TileLayout {
+// Wrap the tile in a frame with its name, highlighted when focused.
+// The size constraints of the tile are for the inside of the frame.
+func boxed(tile tl.Tile) tl.Tile {
+	return tl.Bordered(tile, tl.NewBorderOptions())
+}
+
+func initialModelMinimal() (*tl.TileLayout, error) {
 	root := tl.NewRoot(tl.Vertical)
-	box := tiles.NewViewportTileMinimal(tl.Size{Weight: 1.00}, "Box1", true)
+	box := tiles.NewViewportTileMinimal(tl.Size{Weight: 1.00}, "Box1")
 	status := tiles.NewTextTile(tl.Size{FixedHeight: 1}, "Status", "I am the status tile. I have a fixed height of 1 and take up 100% space.")
-	root.Add(&box)
-	root.Add(&status)
-	return root
+	opts := tl.NewBorderOptions()
+	opts.Footer = "n: next layout"
+	if err := errors.Join(
+		root.Add(tl.Bordered(&box, opts)),
+		root.Add(&status),
+	); err != nil {
+		return nil, err
+	}
+	return root, nil
 }
 
-func initialModelWithConstraints() tl.TileLayout {
+func initialModelWithConstraints() (*tl.TileLayout, error) {
 	// create the root layout
 	root := tl.NewRoot(tl.Vertical)
-	border := true
 	// create the tiles and sub-layouts
 	contentArea := tl.NewTileLayout("ContentArea", tl.Horizontal, tl.Size{Weight: 1.0})
 	status := tiles.NewTextTile(tl.Size{FixedHeight: 1}, "Status", "I am the status tile. I have a fixed height of 1 and take up 100% space.")
-	overview := tiles.NewLayoutOverviewTile(tl.Size{Weight: 0.3}, "Layout overview", border, &root)
+	overview := tiles.NewLayoutOverviewTile(tl.Size{Weight: 0.3}, "Layout overview", root)
 	rightArea := tl.NewTileLayout("RightArea", tl.Vertical, tl.Size{Weight: 0.6})
-	box3 := tiles.NewViewportTile(tl.Size{Weight: 0.20, MinHeight: 6, MaxWidth: 90}, "Box3", border)
-	box4 := tiles.NewViewportTile(tl.Size{Weight: 0.30, MinWidth: 40, MaxWidth: 50}, "Box4", border)
+	box3 := tiles.NewViewportTile(tl.Size{Weight: 0.20, MinHeight: 4, MaxWidth: 88}, "Box3")
+	box4 := tiles.NewViewportTile(tl.Size{Weight: 0.30, MinWidth: 38, MaxWidth: 48}, "Box4")
 	rightAreaSub := tl.NewTileLayout("RightAreaSubLayout", tl.Horizontal, tl.Size{Weight: 0.5})
-	box5 := tiles.NewViewportTile(tl.Size{Weight: 0.40, MaxHeight: 8}, "Box5", border)
-	box6 := tiles.NewViewportTile(tl.Size{Weight: 0.60, MaxWidth: 40, MaxHeight: 14}, "Box6", border)
+	box5 := tiles.NewViewportTile(tl.Size{Weight: 0.40, MaxHeight: 6}, "Box5")
+	box6 := tiles.NewViewportTile(tl.Size{Weight: 0.60, MaxWidth: 38, MaxHeight: 12}, "Box6")
+
+	// allow resizing the tiles with the mouse
+	contentArea.Splitters = true
+	rightArea.Splitters = true
+	rightAreaSub.Splitters = true
+
+	// center the boxes limited by their max sizes
+	rightArea.Align = tl.AlignCenter
+	rightAreaSub.Justify = tl.JustifySpaceAround
 
 	// add the tiles and sub-layouts to the layouts
-	root.Add(&contentArea)
-	root.Add(&status)
-	contentArea.Add(&overview)
-	contentArea.Add(&rightArea)
-	rightArea.Add(&box3)
-	rightArea.Add(&box4)
-	rightArea.Add(&rightAreaSub)
-	rightAreaSub.Add(&box5)
-	rightAreaSub.Add(&box6)
-
-	return root
+	if err := errors.Join(
+		root.Add(contentArea),
+		root.Add(&status),
+		contentArea.Add(boxed(&overview)),
+		contentArea.Add(rightArea),
+		rightArea.Add(boxed(&box3)),
+		rightArea.Add(boxed(&box4)),
+		rightArea.Add(rightAreaSub),
+		rightAreaSub.Add(boxed(&box5)),
+		rightAreaSub.Add(boxed(&box6)),
+	); err != nil {
+		return nil, err
+	}
+
+	return root, nil
 }
 
-func initialModelWeightsOnly() tl.TileLayout {
+func initialModelWeightsOnly() (*tl.TileLayout, error) {
 	root := tl.NewRoot(tl.Vertical)
 	sub1 := tl.NewTileLayout("Sub-1", tl.Horizontal, tl.Size{Weight: 1.0})
 	status := tiles.NewTextTile(tl.Size{FixedHeight: 1}, "Status", "I am the status tile. I have a fixed height of 1 and take up 100% space.")
-	box1 := tiles.NewViewportTile(tl.Size{Weight: 0.33}, "Box1", true)
-	box2 := tiles.NewViewportTile(tl.Size{Weight: 0.33}, "Box2", true)
-	sub1.Add(&box1)
-	sub1.Add(&box2)
+	box1 := tiles.NewViewportTile(tl.Size{Weight: 0.33}, "Box1")
+	box2 := tiles.NewViewportTile(tl.Size{Weight: 0.33}, "Box2")
+	if err := errors.Join(
+		sub1.Add(boxed(&box1)),
+		sub1.Add(boxed(&box2)),
+	); err != nil {
+		return nil, err
+	}
 
 	sub2 := tl.NewTileLayout("Sub-2", tl.Vertical, tl.Size{Weight: 0.33})
-	box3 := tiles.NewViewportTile(tl.Size{Weight: 0.20}, "Box3", true)
-	box4 := tiles.NewViewportTile(tl.Size{Weight: 0.30}, "Box4", true)
-	sub2.Add(&box3)
-	sub2.Add(&box4)
+	box3 := tiles.NewViewportTile(tl.Size{Weight: 0.20}, "Box3")
+	box4 := tiles.NewViewportTile(tl.Size{Weight: 0.30}, "Box4")
+	if err := errors.Join(
+		sub2.Add(boxed(&box3)),
+		sub2.Add(boxed(&box4)),
+	); err != nil {
+		return nil, err
+	}
 
 	subsub1 := tl.NewTileLayout("Sub-2-Sub-1", tl.Horizontal, tl.Size{Weight: 0.5})
-	box5 := tiles.NewViewportTile(tl.Size{Weight: 0.40}, "Box5", true)
-	box6 := tiles.NewViewportTile(tl.Size{Weight: 0.60}, "Box6", true)
-	subsub1.Add(&box5)
-	subsub1.Add(&box6)
-	sub2.Add(&subsub1)
-	sub1.Add(&sub2)
-
-	root.Add(&sub1)
-	root.Add(&status)
-	return root
+	box5 := tiles.NewViewportTile(tl.Size{Weight: 0.40}, "Box5")
+	box6 := tiles.NewViewportTile(tl.Size{Weight: 0.60}, "Box6")
+	if err := errors.Join(
+		subsub1.Add(boxed(&box5)),
+		subsub1.Add(boxed(&box6)),
+		sub2.Add(subsub1),
+		sub1.Add(sub2),
+
+		root.Add(sub1),
+		root.Add(&status),
+	); err != nil {
+		return nil, err
+	}
+	return root, nil
 }
 
-func initialModelManyLayouts() tl.TileLayout {
+func initialModelManyLayouts() (*tl.TileLayout, error) {
 	// create a root
 	root := tl.NewRoot(tl.Vertical)
 
@@ -92,48 +131,101 @@ func initialModelManyLayouts() tl.TileLayout {
 	leftBottom := tl.NewTileLayout("LeftBottom", tl.Horizontal, tl.Size{Weight: .5})
 
 	// tiles for left top
-	ltb1 := tiles.NewLayoutOverviewTile(tl.Size{Weight: 0.6}, "lt-b1", true, &root)
-	ltb2 := tiles.NewViewportTile(tl.Size{Weight: 0.4}, "lt-b2", true)
+	ltb1 := tiles.NewLayoutOverviewTile(tl.Size{Weight: 0.6}, "lt-b1", root)
+	ltb2 := tiles.NewViewportTile(tl.Size{Weight: 0.4}, "lt-b2")
 
 	// tiles for left bottom
-	lbb1 := tiles.NewViewportTile(tl.Size{Weight: 0.3}, "lb-b1", true)
-	lbb2 := tiles.NewViewportTile(tl.Size{Weight: 0.7}, "lb-b2", true)
+	lbb1 := tiles.NewViewportTile(tl.Size{Weight: 0.3}, "lb-b1")
+	lbb2 := tiles.NewViewportTile(tl.Size{Weight: 0.7}, "lb-b2")
 
 	// tiles for middle
-	mb1 := tiles.NewViewportTile(tl.Size{Weight: 0.33}, "m-b1", true)
-	mb2 := tiles.NewViewportTile(tl.Size{Weight: 0.33}, "m-b2", true)
-	mb3 := tiles.NewViewportTile(tl.Size{Weight: 0.33}, "m-b3", true)
+	mb1 := tiles.NewViewportTile(tl.Size{Weight: 0.33}, "m-b1")
+	mb2 := tiles.NewViewportTile(tl.Size{Weight: 0.33}, "m-b2")
+	mb3 := tiles.NewViewportTile(tl.Size{Weight: 0.33}, "m-b3")
 
 	// tiles for right
-	rb1 := tiles.NewViewportTile(tl.Size{Weight: 0.25}, "r-b1", true)
-	rb2 := tiles.NewViewportTile(tl.Size{Weight: 0.25}, "r-b2", true)
-	rb3 := tiles.NewViewportTile(tl.Size{Weight: 0.25}, "r-b3", true)
-	rb4 := tiles.NewViewportTile(tl.Size{Weight: 0.25}, "r-b4", true)
+	rb1 := tiles.NewViewportTile(tl.Size{Weight: 0.25}, "r-b1")
+	rb2 := tiles.NewViewportTile(tl.Size{Weight: 0.25}, "r-b2")
+	rb3 := tiles.NewViewportTile(tl.Size{Weight: 0.25}, "r-b3")
+	rb4 := tiles.NewViewportTile(tl.Size{Weight: 0.25}, "r-b4")
 
 	// add the tiles to their layouts
-	leftTop.Add(&ltb1)
-	leftTop.Add(&ltb2)
-	leftBottom.Add(&lbb1)
-	leftBottom.Add(&lbb2)
-
-	middle.Add(&mb1)
-	middle.Add(&mb2)
-	middle.Add(&mb3)
-
-	right.Add(&rb1)
-	right.Add(&rb2)
-	right.Add(&rb3)
-	right.Add(&rb4)
-
-	// add the sub-layouts to their layouts
-	left.Add(&leftTop)
-	left.Add(&leftBottom)
-
-	content.Add(&left)
-	content.Add(&middle)
-	content.Add(&right)
-
-	root.Add(&content)
-	root.Add(&status)
-	return root
+	if err := errors.Join(
+		leftTop.Add(boxed(&ltb1)),
+		leftTop.Add(boxed(&ltb2)),
+		leftBottom.Add(boxed(&lbb1)),
+		leftBottom.Add(boxed(&lbb2)),
+
+		middle.Add(boxed(&mb1)),
+		middle.Add(boxed(&mb2)),
+		middle.Add(boxed(&mb3)),
+
+		right.Add(boxed(&rb1)),
+		right.Add(boxed(&rb2)),
+		right.Add(boxed(&rb3)),
+		right.Add(boxed(&rb4)),
+
+		// add the sub-layouts to their layouts
+		left.Add(leftTop),
+		left.Add(leftBottom),
+
+		content.Add(left),
+		content.Add(middle),
+		content.Add(right),
+	); err != nil {
+		return nil, err
+	}
+
+	// keep the columns apart and the status line off the edge
+	content.Gap = 1
+	content.Padding = tl.NewSpacing(0, 1)
+
+	if err := errors.Join(
+		root.Add(content),
+		root.Add(&status),
+	); err != nil {
+		return nil, err
+	}
+	return root, nil
+}
+
+func initialModelBorders() (*tl.TileLayout, error) {
+	root := tl.NewRoot(tl.Vertical)
+
+	// the content layout draws the borders, shared by the tiles and the nested layouts
+	content := tl.NewTileLayout("Content", tl.Horizontal, tl.Size{Weight: 1.0})
+	content.Borders = tl.NewBorders()
+	content.Borders.Border = lipgloss.RoundedBorder()
+	content.Borders.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("62"))
+	content.Borders.TitleStyle = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("205"))
+	content.Splitters = true
+	status := tiles.NewTextTile(tl.Size{FixedHeight: 1}, "Status", "The tiles share the borders drawn by their layouts.")
+
+	right := tl.NewTileLayout("Right", tl.Vertical, tl.Size{Weight: 0.6})
+	right.Borders = tl.NewBorders()
+	right.Splitters = true
+	rightBottom := tl.NewTileLayout("RightBottom", tl.Horizontal, tl.Size{Weight: 0.5})
+	rightBottom.Borders = tl.NewBorders()
+	rightBottom.Splitters = true
+
+	// the tiles draw no box of their own, they get the rect inside the lines
+	overview := tiles.NewLayoutOverviewTile(tl.Size{Weight: 0.4}, "Layout overview", root)
+	box1 := tiles.NewViewportTile(tl.Size{Weight: 0.5}, "Box1")
+	box2 := tiles.NewViewportTile(tl.Size{Weight: 0.5}, "Box2")
+	box3 := tiles.NewViewportTile(tl.Size{Weight: 0.5}, "Box3")
+
+	if err := errors.Join(
+		rightBottom.Add(&box2),
+		rightBottom.Add(&box3),
+		right.Add(&box1),
+		right.Add(rightBottom),
+		content.Add(&overview),
+		content.Add(right),
+
+		root.Add(content),
+		root.Add(&status),
+	); err != nil {
+		return nil, err
+	}
+	return root, nil
 }
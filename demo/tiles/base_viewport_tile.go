@@ -54,47 +54,35 @@ func style(target, constraint1, constraint2, constraint3 int) string {
 
 type BaseViewportTile struct {
 	*tl.BaseTile
-	Content   viewport.Model
-	BoxBorder bool
+	Content viewport.Model
 }
 
-func NewBaseViewportTile(size tl.Size, name string, boxBorder bool) BaseViewportTile {
+func NewBaseViewportTile(size tl.Size, name string) BaseViewportTile {
 	vp := viewport.New(10, 10)
 	return BaseViewportTile{
 		BaseTile: &tl.BaseTile{
 			Name: name,
 			Size: size,
 		},
-		Content:   vp,
-		BoxBorder: boxBorder,
+		Content: vp,
 	}
 }
 
 func (vt *BaseViewportTile) Init() tea.Cmd { return nil }
 
+// Fit the viewport into the new size of the tile.
+// The frame of a tile wrapped by tl.Bordered is not part of the size.
+func (vt *BaseViewportTile) Resize(old, new tl.Rect) tea.Cmd {
+	vt.Content.Width = new.Width
+	vt.Content.Height = new.Height
+	vt.Content.SetContent("BaseViewportTIle only sets its size and this dummy text.")
+	return nil
+}
+
 func (vt *BaseViewportTile) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
-	switch msg := msg.(type) {
-	case tl.TileUpdatedMsg:
-		if vt.GetName() != msg.Name {
-			// only react to parent updates
-			return vt, nil
-		}
-		newWidth := vt.Size.Width
-		newHeight := vt.Size.Height
-		if vt.BoxBorder {
-			newWidth -= BOX_PAD
-			newHeight -= BOX_PAD
-		}
-		vt.Content.Width = newWidth
-		vt.Content.Height = newHeight
-		vt.Content.SetContent("BaseViewportTIle only sets its size and this dummy text.")
-	}
 	return vt, nil
 }
 
 func (vt *BaseViewportTile) View() string {
-	if vt.BoxBorder {
-		return RenderBox(vt.Name, vt.Content.View(), vt.Size)
-	}
 	return vt.Content.View()
 }
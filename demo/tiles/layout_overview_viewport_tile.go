@@ -14,32 +14,40 @@ type LayoutOverviewTile struct {
 	Layout *tl.TileLayout
 }
 
-func NewLayoutOverviewTile(size tl.Size, name string, boxBorder bool, layout *tl.TileLayout) LayoutOverviewTile {
-	base := NewBaseViewportTile(size, name, boxBorder)
+func NewLayoutOverviewTile(size tl.Size, name string, layout *tl.TileLayout) LayoutOverviewTile {
+	base := NewBaseViewportTile(size, name)
 	return LayoutOverviewTile{
 		BaseViewportTile: base,
 		Layout:           layout,
 	}
 }
 
-func (lot *LayoutOverviewTile) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
-	switch msg := msg.(type) {
-	case tl.TileUpdatedMsg:
-		if msg.Name == lot.Name {
-			lot.BaseViewportTile.Update(msg)
-			var sb strings.Builder
-			fmt.Fprintf(&sb, "---Tree---\n")
-			printLayoutTree(&sb, *lot.Layout, "")
+// Fit the viewport into the new size and print the overview of the layout.
+func (lot *LayoutOverviewTile) Resize(old, new tl.Rect) tea.Cmd {
+	lot.BaseViewportTile.Resize(old, new)
+	lot.printOverview()
+	return nil
+}
 
-			fmt.Fprintf(&sb, "\n---Sizes---\n")
-			printLayoutSizes(&sb, *lot.Layout)
+// Print the tree and the sizes of the layout.
+func (lot *LayoutOverviewTile) printOverview() {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "---Tree---\n")
+	printLayoutTree(&sb, lot.Layout)
 
-			fmt.Fprintf(&sb, "\n---Tile---\n")
-			parent := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("62")).Render(lot.Parent.GetName())
-			fmt.Fprintf(&sb, "Parent: %v\n%v", parent, printSize(lot.Size))
-			lot.Content.SetContent(sb.String())
-			return lot, nil
-		}
+	fmt.Fprintf(&sb, "\n---Sizes---\n")
+	printLayoutSizes(&sb, lot.Layout)
+
+	fmt.Fprintf(&sb, "\n---Tile---\n")
+	parent := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("62")).Render(lot.Parent.GetName())
+	fmt.Fprintf(&sb, "Parent: %v\n%v", parent, printSize(lot.Size))
+	lot.Content.SetContent(sb.String())
+}
+
+func (lot *LayoutOverviewTile) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if _, ok := msg.(tl.LayoutUpdatedMsg); ok {
+		// the nested layouts are layouted after this tile was resized
+		lot.printOverview()
 	}
 	var cmd tea.Cmd
 	cmds := []tea.Cmd{}
@@ -48,39 +56,37 @@ func (lot *LayoutOverviewTile) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return lot, tea.Batch(cmds...)
 }
 
-func printLayoutSizes(sb *strings.Builder, l tl.TileLayout) {
-	for _, tile := range l.Tiles {
-		if tl, ok := tile.(tl.TileLayout); ok {
-			printLayoutSizes(sb, tl)
-		}
+func printLayoutSizes(sb *strings.Builder, l *tl.TileLayout) {
+	for layout := range l.Layouts() {
+		fmt.Fprintf(sb, "%v(%v)\n%v\n", layoutName(layout), layoutDirection(layout), printSize(layout.Size))
 	}
-	name := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("62")).Render(l.Name)
-	direction := ""
-	switch l.Direction {
-	case tl.Horizontal:
-		direction = lipgloss.NewStyle().Foreground(lipgloss.Color("241")).Render("Horizontal")
-	case tl.Vertical:
-		direction = lipgloss.NewStyle().Foreground(lipgloss.Color("241")).Render("Vertical")
+}
+
+func printLayoutTree(sb *strings.Builder, l *tl.TileLayout) {
+	for tile := range l.All() {
+		depth := 0
+		for range l.Ancestors(tile) {
+			depth++
+		}
+		prefix := strings.Repeat("  ", depth)
+		if layout, ok := tile.(*tl.TileLayout); ok {
+			fmt.Fprintf(sb, "%s%v(%v)\n", prefix, layoutName(layout), layoutDirection(layout))
+		} else {
+			fmt.Fprintf(sb, "%s%v\n", prefix, tile.GetName())
+		}
 	}
-	fmt.Fprintf(sb, "%v(%v)\n%v\n", name, direction, printSize(l.Size))
+}
 
+func layoutName(l *tl.TileLayout) string {
+	return lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("62")).Render(l.Name)
 }
 
-func printLayoutTree(sb *strings.Builder, l tl.TileLayout, prefix string) {
-	name := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("62")).Render(l.Name)
-	direction := ""
+func layoutDirection(l *tl.TileLayout) string {
 	switch l.Direction {
 	case tl.Horizontal:
-		direction = lipgloss.NewStyle().Foreground(lipgloss.Color("241")).Render("Horizontal")
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("241")).Render("Horizontal")
 	case tl.Vertical:
-		direction = lipgloss.NewStyle().Foreground(lipgloss.Color("241")).Render("Vertical")
-	}
-	fmt.Fprintf(sb, "%s%v(%v)\n", prefix, name, direction)
-	for _, tile := range l.Tiles {
-		if tl, ok := tile.(tl.TileLayout); ok {
-			printLayoutTree(sb, tl, prefix+"  ")
-		} else {
-			fmt.Fprintf(sb, "%s%v\n", prefix+"  ", tile.GetName())
-		}
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("241")).Render("Vertical")
 	}
+	return ""
 }
@@ -28,6 +28,9 @@ func NewTextTile(size tl.Size, name string, content string) TextTile {
 
 func (ct *TextTile) Init() tea.Cmd { return nil }
 
+// The status text does not take the focus.
+func (ct *TextTile) Focusable() bool { return false }
+
 func (ct *TextTile) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tl.LayoutUpdatedMsg:
@@ -43,10 +46,6 @@ func (ct *TextTile) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			fmt.Fprintf(&sb, "%v[%v] ", k, ct.Data[k])
 		}
 		ct.Content = sb.String()
-	case tl.TileUpdatedMsg:
-		if ct.GetName() == msg.Name {
-			//
-		}
 	}
 
 	return ct, nil
@@ -12,8 +12,8 @@ type ViewportTile struct {
 	*BaseViewportTile
 }
 
-func NewViewportTile(size tl.Size, name string, boxBorder bool) ViewportTile {
-	base := NewBaseViewportTile(size, name, boxBorder)
+func NewViewportTile(size tl.Size, name string) ViewportTile {
+	base := NewBaseViewportTile(size, name)
 	return ViewportTile{
 		BaseViewportTile: &base,
 	}
@@ -21,19 +21,20 @@ func NewViewportTile(size tl.Size, name string, boxBorder bool) ViewportTile {
 
 func (vt *ViewportTile) Init() tea.Cmd { return nil }
 
-func (vt *ViewportTile) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+// Fit the viewport into the new size and show the size of the tile.
+func (vt *ViewportTile) Resize(old, new tl.Rect) tea.Cmd {
+	vt.BaseViewportTile.Resize(old, new)
+	parent := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("62")).Render(vt.Parent.GetName())
+	text := fmt.Sprintf("Parent: %v\n%v", parent, printSize(vt.Size))
+	text = lipgloss.NewStyle().Width(vt.BaseViewportTile.Content.Width).Render(text)
+	vt.Content.SetContent(text)
+	return nil
+}
 
-	switch msg := msg.(type) {
-	case tl.TileUpdatedMsg:
-		if vt.Name != msg.Name {
-			// only react to parent updates
-			return vt, nil
-		}
-		vt.BaseViewportTile.Update(msg)
-		parent := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("62")).Render(vt.Parent.GetName())
-		text := fmt.Sprintf("Parent: %v\n%v", parent, printSize(vt.Size))
-		text = lipgloss.NewStyle().Width(vt.BaseViewportTile.Content.Width).Render(text)
-		vt.Content.SetContent(text)
+func (vt *ViewportTile) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok && msg.String() == "x" {
+		// let the layouts (or the app) decide what closing means
+		return vt, tl.Emit(vt, "close", nil)
 	}
 	var cmd tea.Cmd
 	cmds := []tea.Cmd{}
@@ -9,8 +9,8 @@ type ViewportTileMinimal struct {
 	*BaseViewportTile
 }
 
-func NewViewportTileMinimal(size tl.Size, name string, boxBorder bool) ViewportTileMinimal {
-	base := NewBaseViewportTile(size, name, boxBorder)
+func NewViewportTileMinimal(size tl.Size, name string) ViewportTileMinimal {
+	base := NewBaseViewportTile(size, name)
 	return ViewportTileMinimal{
 		BaseViewportTile: &base,
 	}
@@ -18,29 +18,13 @@ func NewViewportTileMinimal(size tl.Size, name string, boxBorder bool) ViewportT
 
 func (vt *ViewportTileMinimal) Init() tea.Cmd { return nil }
 
-func (vt *ViewportTileMinimal) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
-	switch msg := msg.(type) {
-	case tl.TileUpdatedMsg:
-		if vt.Name != msg.Name {
-			// only react to parent updates
-			return vt, nil
-		}
-		newWidth := vt.Size.Width
-		newHeight := vt.Size.Height
-		if vt.BoxBorder {
-			newWidth -= BOX_PAD
-			newHeight -= BOX_PAD
-		}
-		vt.Content.Width = newWidth
-		vt.Content.Height = newHeight
-		vt.Content.SetContent("Press 'tab' to cycle to other layouts.\nTo quit press 'q' or 'ctrl+c'")
-	}
-	return vt, nil
+// Fit the viewport into the new size and show the help text.
+func (vt *ViewportTileMinimal) Resize(old, new tl.Rect) tea.Cmd {
+	vt.BaseViewportTile.Resize(old, new)
+	vt.Content.SetContent("Press 'n' to cycle to other layouts.\nPress 'tab' to cycle the focus.\nPress 'x' to close the focused box in the other layouts.\nTo quit press 'q' or 'ctrl+c'")
+	return nil
 }
 
-func (vt *ViewportTileMinimal) View() string {
-	if vt.BoxBorder {
-		return RenderBox(vt.Name, vt.Content.View(), vt.Size)
-	}
-	return vt.Content.View()
+func (vt *ViewportTileMinimal) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	return vt, nil
 }
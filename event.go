@@ -0,0 +1,65 @@
+package tilelayout
+
+import tea "github.com/charmbracelet/bubbletea"
+
+// An event emitted by a tile, like "close" or "selection-changed". The event bubbles up through
+// the parent layouts of the source tile, whose handlers may change the Name and the Data
+// of the event for the next layouts, or stop it.
+type Event struct {
+	Name   string
+	Data   any
+	Source Tile
+	// The layout whose handlers are handling the event.
+	Layout  *TileLayout
+	stopped bool
+}
+
+// Stop the bubbling of the event: no more handlers are called.
+func (e *Event) Stop() {
+	e.stopped = true
+}
+
+// Returns true if a handler stopped the event.
+func (e *Event) Stopped() bool {
+	return e.stopped
+}
+
+// Handler of the events bubbling through a layout.
+type EventHandler func(e *Event) tea.Cmd
+
+// Emit an event from the tile, typically returned from its Update. The event is dispatched
+// by the root layout: it bubbles up from the parent of the tile to the root, calling the handlers
+// registered with OnEvent for the name of the event. The handlers of the root come last,
+// which makes them the place for the app to handle the events no layout consumed.
+func Emit(source Tile, name string, data any) tea.Cmd {
+	e := &Event{Name: name, Data: data, Source: source}
+	return func() tea.Msg { return e }
+}
+
+// Register the handler for the events with the name emitted by the tiles in the tree of the layout.
+// The handlers of a layout are called in the order of their registration.
+func (tl *TileLayout) OnEvent(name string, handler EventHandler) {
+	if tl.handlers == nil {
+		tl.handlers = make(map[string][]EventHandler)
+	}
+	tl.handlers[name] = append(tl.handlers[name], handler)
+}
+
+// Bubble the event up through the parent layouts of its source, up to this layout.
+// Events of tiles no longer in the tree are dropped.
+func (tl *TileLayout) dispatch(e *Event) tea.Cmd {
+	if e.Source == nil || tl.pathTo(e.Source) == nil {
+		return nil
+	}
+	var cmds []tea.Cmd
+	for layout := range tl.Ancestors(e.Source) {
+		e.Layout = layout
+		for _, handler := range layout.handlers[e.Name] {
+			cmds = append(cmds, handler(e))
+			if e.stopped {
+				return tea.Batch(cmds...)
+			}
+		}
+	}
+	return tea.Batch(cmds...)
+}
@@ -0,0 +1,193 @@
+package tilelayout
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Message sent to a tile when it gains the focus.
+type FocusMsg struct {
+	Name string
+}
+
+// Message sent to a tile when it loses the focus.
+type BlurMsg struct {
+	Name string
+}
+
+// Tiles implementing Focusable can opt out of receiving the focus by returning false.
+// All other leaf tiles are focusable.
+type Focusable interface {
+	Focusable() bool
+}
+
+// Tiles implementing KeyConsumer may decline key messages while focused.
+// Declined keys bubble up to the KeyHandler of the parent layouts.
+type KeyConsumer interface {
+	ConsumesKey(msg tea.KeyMsg) bool
+}
+
+// Handler for key messages declined by the focused tile (or when no tile is focused).
+// Returns true if the key was handled, stopping the bubbling.
+type KeyHandler func(msg tea.KeyMsg) (tea.Cmd, bool)
+
+// The key bindings of the root layout.
+type KeyMap struct {
+	FocusNext  key.Binding
+	FocusPrev  key.Binding
+	FocusLeft  key.Binding
+	FocusRight key.Binding
+	FocusUp    key.Binding
+	FocusDown  key.Binding
+	Grow       key.Binding
+	Shrink     key.Binding
+	Equalize   key.Binding
+}
+
+// Creates the default key bindings: tab and shift+tab cycle the focus,
+// alt+arrows (or alt+h/j/k/l) move the focus to the adjacent tile,
+// alt+= and alt+- grow and shrink the focused tile and alt+0 equalizes it with its siblings.
+func DefaultKeyMap() KeyMap {
+	return KeyMap{
+		FocusNext:  key.NewBinding(key.WithKeys("tab"), key.WithHelp("tab", "next tile")),
+		FocusPrev:  key.NewBinding(key.WithKeys("shift+tab"), key.WithHelp("shift+tab", "previous tile")),
+		FocusLeft:  key.NewBinding(key.WithKeys("alt+left", "alt+h"), key.WithHelp("alt+←", "tile to the left")),
+		FocusRight: key.NewBinding(key.WithKeys("alt+right", "alt+l"), key.WithHelp("alt+→", "tile to the right")),
+		FocusUp:    key.NewBinding(key.WithKeys("alt+up", "alt+k"), key.WithHelp("alt+↑", "tile above")),
+		FocusDown:  key.NewBinding(key.WithKeys("alt+down", "alt+j"), key.WithHelp("alt+↓", "tile below")),
+		Grow:       key.NewBinding(key.WithKeys("alt+="), key.WithHelp("alt+=", "grow tile")),
+		Shrink:     key.NewBinding(key.WithKeys("alt+-"), key.WithHelp("alt+-", "shrink tile")),
+		Equalize:   key.NewBinding(key.WithKeys("alt+0"), key.WithHelp("alt+0", "equalize tiles")),
+	}
+}
+
+// Returns the focused tile or nil. For a tile wrapped by Bordered, the wrapper is returned.
+func (tl *TileLayout) Focused() Tile {
+	if tl.focused == 0 {
+		return nil
+	}
+	tile, _ := tl.FindID(tl.focused)
+	return tile
+}
+
+// Move the focus to the tile. The previously focused tile receives BlurMsg
+// and the tile receives FocusMsg. Passing nil clears the focus, a tile which is not
+// in the tree is ignored. A tile wrapped by Bordered is focused through its wrapper.
+func (tl *TileLayout) Focus(tile Tile) tea.Cmd {
+	var id ID
+	if tile != nil {
+		found, ok := tl.FindID(tile.GetID())
+		if !ok || !sameTile(found, tile) {
+			return nil
+		}
+		tile, id = found, found.GetID()
+	}
+	if tl.focused == id {
+		return nil
+	}
+	var cmds []tea.Cmd
+	if previous := tl.Focused(); previous != nil {
+		cmd, _ := tl.updateTile(previous, BlurMsg{Name: previous.GetName()})
+		cmds = append(cmds, cmd)
+	}
+	tl.focused = id
+	if tile != nil {
+		cmd, _ := tl.updateTile(tile, FocusMsg{Name: tile.GetName()})
+		cmds = append(cmds, cmd)
+	}
+	return tea.Batch(cmds...)
+}
+
+// Move the focus to the next focusable tile in tree order, wrapping around.
+func (tl *TileLayout) FocusNext() tea.Cmd {
+	return tl.cycleFocus(1)
+}
+
+// Move the focus to the previous focusable tile in tree order, wrapping around.
+func (tl *TileLayout) FocusPrev() tea.Cmd {
+	return tl.cycleFocus(-1)
+}
+
+func (tl *TileLayout) cycleFocus(step int) tea.Cmd {
+	tiles := tl.focusable()
+	if len(tiles) == 0 {
+		return nil
+	}
+	current := -1
+	for i, tile := range tiles {
+		if tile.GetID() == tl.focused {
+			current = i
+			break
+		}
+	}
+	if current < 0 {
+		if step > 0 {
+			return tl.Focus(tiles[0])
+		}
+		return tl.Focus(tiles[len(tiles)-1])
+	}
+	next := (current + step + len(tiles)) % len(tiles)
+	return tl.Focus(tiles[next])
+}
+
+// Collect the focusable tiles in tree order. Hidden tiles are not focusable.
+func (tl *TileLayout) focusable() []Tile {
+	var tiles []Tile
+	for tile := range tl.Leaves() {
+		if f, ok := tile.(Focusable); ok && !f.Focusable() || isHidden(tile) {
+			continue
+		}
+		tiles = append(tiles, tile)
+	}
+	return tiles
+}
+
+// Handle a key message:
+//  1. The keys of the KeyMap move or resize the focused tile.
+//  2. The key is delivered to the focused tile only.
+//  3. If the focused tile declines it (see KeyConsumer), the key bubbles up through the
+//     KeyHandlers of its parent layouts until one handles it.
+func (tl *TileLayout) handleKey(msg tea.KeyMsg) tea.Cmd {
+	switch {
+	case key.Matches(msg, tl.KeyMap.FocusNext):
+		return tl.FocusNext()
+	case key.Matches(msg, tl.KeyMap.FocusPrev):
+		return tl.FocusPrev()
+	case key.Matches(msg, tl.KeyMap.FocusLeft):
+		return tl.FocusDirection(FocusLeft)
+	case key.Matches(msg, tl.KeyMap.FocusRight):
+		return tl.FocusDirection(FocusRight)
+	case key.Matches(msg, tl.KeyMap.FocusUp):
+		return tl.FocusDirection(FocusUp)
+	case key.Matches(msg, tl.KeyMap.FocusDown):
+		return tl.FocusDirection(FocusDown)
+	case key.Matches(msg, tl.KeyMap.Grow):
+		return tl.ResizeFocused(max(1, tl.ResizeStep))
+	case key.Matches(msg, tl.KeyMap.Shrink):
+		return tl.ResizeFocused(-max(1, tl.ResizeStep))
+	case key.Matches(msg, tl.KeyMap.Equalize):
+		return tl.EqualizeFocused()
+	}
+	ancestors := []*TileLayout{tl}
+	focused := tl.Focused()
+	if focused == nil {
+		// nothing is focused, or the focused tile is no longer in the tree
+		tl.focused = 0
+	} else {
+		ancestors = tl.pathTo(focused)
+		consumer, ok := focused.(KeyConsumer)
+		if !ok || consumer.ConsumesKey(msg) {
+			cmd, _ := tl.updateTile(focused, msg)
+			return cmd
+		}
+	}
+	for i := len(ancestors) - 1; i >= 0; i-- {
+		if ancestors[i].KeyHandler == nil {
+			continue
+		}
+		if cmd, handled := ancestors[i].KeyHandler(msg); handled {
+			return cmd
+		}
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package tilelayout
+
+import tea "github.com/charmbracelet/bubbletea"
+
+// Direction of the spatial focus navigation.
+type FocusDir int
+
+const (
+	FocusLeft FocusDir = iota
+	FocusRight
+	FocusUp
+	FocusDown
+)
+
+// Move the focus to the tile spatially adjacent to the focused one in the direction,
+// based on the computed geometry, regardless of how the layouts are nested.
+// Of the tiles in the direction, the ones overlapping the focused tile on the other
+// axis are preferred, then the nearest, then the one with the largest overlap.
+// If no tile is focused, the first focusable tile receives the focus.
+func (tl *TileLayout) FocusDirection(dir FocusDir) tea.Cmd {
+	focused := tl.Focused()
+	if focused == nil {
+		return tl.FocusNext()
+	}
+	current := focused.GetRect()
+	var best Tile
+	var bestScore adjacencyScore
+	for _, tile := range tl.focusable() {
+		rect := tile.GetRect()
+		if sameTile(tile, focused) || rect.Width <= 0 || rect.Height <= 0 {
+			continue
+		}
+		score, ok := adjacency(current, rect, dir)
+		if ok && (best == nil || score.closerThan(bestScore)) {
+			best, bestScore = tile, score
+		}
+	}
+	if best == nil {
+		return nil
+	}
+	return tl.Focus(best)
+}
+
+// How close a candidate tile is to the focused tile in a direction.
+type adjacencyScore struct {
+	dist    int
+	overlap int
+	center  int
+}
+
+func (s adjacencyScore) closerThan(other adjacencyScore) bool {
+	if (s.overlap > 0) != (other.overlap > 0) {
+		return s.overlap > 0
+	}
+	if s.dist != other.dist {
+		return s.dist < other.dist
+	}
+	if s.overlap != other.overlap {
+		return s.overlap > other.overlap
+	}
+	return s.center < other.center
+}
+
+// Measure the candidate rect relative to the current one in the direction:
+// the gap between them along the direction, their overlap on the other axis and
+// the distance of their centers on the other axis. Returns false if the candidate
+// is not in the direction.
+func adjacency(current, candidate Rect, dir FocusDir) (adjacencyScore, bool) {
+	var dist int
+	switch dir {
+	case FocusLeft:
+		dist = current.X - (candidate.X + candidate.Width)
+	case FocusRight:
+		dist = candidate.X - (current.X + current.Width)
+	case FocusUp:
+		dist = current.Y - (candidate.Y + candidate.Height)
+	case FocusDown:
+		dist = candidate.Y - (current.Y + current.Height)
+	}
+	if dist < 0 {
+		return adjacencyScore{}, false
+	}
+	start, end := current.Y, current.Y+current.Height
+	candStart, candEnd := candidate.Y, candidate.Y+candidate.Height
+	if dir == FocusUp || dir == FocusDown {
+		start, end = current.X, current.X+current.Width
+		candStart, candEnd = candidate.X, candidate.X+candidate.Width
+	}
+	return adjacencyScore{
+		dist:    dist,
+		overlap: min(end, candEnd) - max(start, candStart),
+		center:  abs((start + end) - (candStart + candEnd)),
+	}, true
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
@@ -0,0 +1,86 @@
+package tilelayout
+
+import "iter"
+
+// Returns an iterator over the layout and all tiles in its tree, in tree order.
+func (tl *TileLayout) All() iter.Seq[Tile] {
+	return func(yield func(Tile) bool) {
+		tl.walk(yield)
+	}
+}
+
+// Returns an iterator over the leaf tiles (the tiles which are not layouts) in tree order.
+func (tl *TileLayout) Leaves() iter.Seq[Tile] {
+	return func(yield func(Tile) bool) {
+		for tile := range tl.All() {
+			if _, ok := tile.(*TileLayout); ok {
+				continue
+			}
+			if !yield(tile) {
+				return
+			}
+		}
+	}
+}
+
+// Returns an iterator over the layout and all nested layouts in tree order.
+func (tl *TileLayout) Layouts() iter.Seq[*TileLayout] {
+	return func(yield func(*TileLayout) bool) {
+		for tile := range tl.All() {
+			if layout, ok := tile.(*TileLayout); ok && !yield(layout) {
+				return
+			}
+		}
+	}
+}
+
+// Returns an iterator over the parent layouts of the tile, from its parent up to this layout
+// (or up to the root, if the tile is not in the tree of this layout).
+func (tl *TileLayout) Ancestors(tile Tile) iter.Seq[*TileLayout] {
+	return func(yield func(*TileLayout) bool) {
+		for parent, ok := tile.GetParent().(*TileLayout); ok; parent, ok = parent.GetParent().(*TileLayout) {
+			if !yield(parent) || parent == tl {
+				return
+			}
+		}
+	}
+}
+
+// Find the tile at the path (see Find) with the type T, e.g. FindAs[*MyTile](root, "Content/Editor").
+// Returns false if there is no such tile or it is not a T.
+// For a tile wrapped by Bordered, either the wrapper or the wrapped tile is returned, whichever is a T.
+func FindAs[T Tile](tl *TileLayout, path string) (T, bool) {
+	tile, ok := tl.Find(path)
+	if !ok {
+		var zero T
+		return zero, false
+	}
+	if typed, ok := tile.(T); ok {
+		return typed, true
+	}
+	typed, ok := unwrap(tile).(T)
+	return typed, ok
+}
+
+// Yield the layout and all tiles in its tree in tree order.
+// Returns false if the iteration was stopped.
+func (tl *TileLayout) walk(yield func(Tile) bool) bool {
+	if !yield(tl) {
+		return false
+	}
+	for _, tile := range tl.Tiles {
+		if tile == nil {
+			continue
+		}
+		if layout, ok := tile.(*TileLayout); ok {
+			if !layout.walk(yield) {
+				return false
+			}
+			continue
+		}
+		if !yield(tile) {
+			return false
+		}
+	}
+	return true
+}
@@ -1,13 +1,17 @@
 package tilelayout
 
 import (
+	"errors"
+	"reflect"
+	"strings"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
 
-// The Size structure and constraints for the layout
+// The Size structure and constraints for the layout.
+// Width and Height are computed by the layout and mirror the dimensions of the tile Rect.
 type Size struct {
 	Width       int
 	Height      int
@@ -18,6 +22,12 @@ type Size struct {
 	MaxHeight   int
 	FixedWidth  int
 	FixedHeight int
+	// A hidden tile takes no space in the layout and gets an empty rect.
+	Hidden bool
+	// Space around the tile in its layout, outside of its rect.
+	Margin Spacing
+	// Alignment of the tile on the cross axis of its layout, overriding the Align of the layout.
+	Align Align
 }
 
 // Creates new Size
@@ -54,27 +64,67 @@ type Metrics struct {
 	RenderTime time.Duration
 }
 
+// A layout of tiles. Layouts are always handled through *TileLayout,
+// so they can be nested and the parent pointers of the tiles stay valid.
 type TileLayout struct {
-	*BaseTile
+	BaseTile
 	Tiles            []Tile
 	Direction        Direction
 	TotalFixedWidth  int
 	TotalFixedHeight int
 	Metrics          Metrics
+	// Key bindings handled by the root layout.
+	KeyMap KeyMap
+	// Handles the keys declined by the focused tile in this layout.
+	KeyHandler KeyHandler
+	// Allows resizing the tiles by dragging the edges between them with the mouse.
+	Splitters bool
+	// Number of cells the focused tile is resized by with the Grow/Shrink keys (at least 1).
+	ResizeStep int
+	// Number of cells between the tiles.
+	Gap int
+	// Space between the edges of the layout and its tiles.
+	Padding Spacing
+	// Style of the cells not covered by any tile, like the gaps, paddings and margins.
+	FillStyle lipgloss.Style
+	// Alignment of the tiles on the cross axis.
+	Align Align
+	// Distribution of the leftover space on the main axis.
+	Justify Justify
+	// Lines drawn around and between the tiles, taking the place of a cell of the gap.
+	// Nested layouts with Borders share the lines of the outermost one and its glyphs and styles.
+	Borders *Borders
+	// Collect the tiles whose views do not match their size, see Mismatches. Set on the root layout.
+	Debug bool
+	err   *LayoutError
+	// the ID of the focused tile, as the value tiles are copied by their updates
+	focused     ID
+	drag        *splitterDrag
+	pending     []tea.Cmd
+	initialized bool
+	consumed    map[reflect.Type]bool
+	handlers    map[string][]EventHandler
+	mismatches  []ViewMismatch
+	// positions of the lines between the tiles along the direction, see Borders
+	separators []int
+	borders    map[point]borderCell
 }
 
-func NewRoot(direction Direction) TileLayout {
-	return TileLayout{
-		BaseTile: &BaseTile{
+func NewRoot(direction Direction) *TileLayout {
+	return &TileLayout{
+		BaseTile: BaseTile{
+			ID:   newID(),
 			Name: "Root",
 		},
 		Direction: direction,
+		KeyMap:    DefaultKeyMap(),
 	}
 }
 
-func NewTileLayout(name string, direction Direction, size Size) TileLayout {
-	return TileLayout{
-		BaseTile: &BaseTile{
+func NewTileLayout(name string, direction Direction, size Size) *TileLayout {
+	return &TileLayout{
+		BaseTile: BaseTile{
+			ID:   newID(),
 			Name: name,
 			Size: size,
 		},
@@ -82,12 +132,13 @@ func NewTileLayout(name string, direction Direction, size Size) TileLayout {
 	}
 }
 
-// Add a tile. The parent of the tile is set to the layout.
-func (tl *TileLayout) Add(tile Tile) {
-	tile.SetParent(tl)
-	tl.TotalFixedWidth += tile.GetSize().FixedWidth
-	tl.TotalFixedHeight += tile.GetSize().FixedHeight
-	tl.Tiles = append(tl.Tiles, tile)
+// Add a tile after the other tiles, like InsertAt. The parent of the tile is set to the layout
+// and, if the layout was already layouted, it is layouted again.
+// If the tree was already initialized, the tile is initialized as well,
+// the commands are returned by Cmd or the next Update of the root layout.
+// Returns ErrDuplicateName if the layout already has a tile with the same name.
+func (tl *TileLayout) Add(tile Tile) error {
+	return tl.InsertAt(len(tl.Tiles), tile)
 }
 
 // If the layout have no parent, it's considered root.
@@ -97,43 +148,102 @@ func (tl *TileLayout) isRoot() bool {
 
 // Handle the WindowSizeMsg
 // If the layout is root, set its dimensions to the new window size and weight to 1.0.
-// Proceeds with layouting itself and record its metrics.
-func (tl *TileLayout) handleWindowSizeMsg(msg tea.WindowSizeMsg) {
-	if tl.isRoot() {
-		tl.Size.Width = msg.Width
-		tl.Size.Height = msg.Height
-		tl.Size.Weight = 1
+// The root always starts at the top left corner of the terminal.
+// The root layouts the whole tree, so the changed constraints of the nested tiles are applied
+// as well. A nested layout was already layouted by the root, which sends it the WindowSizeMsg
+// only to report its new size, so there is nothing to do.
+// Returns the tiles whose geometry changed.
+func (tl *TileLayout) handleWindowSizeMsg(msg tea.WindowSizeMsg) []change {
+	if !tl.isRoot() {
+		return nil
 	}
-	start := time.Now()
-	tl.layout()
-	elapsed := time.Since(start)
-	tl.Metrics.RenderTime = elapsed
+	tl.Size.Width = msg.Width
+	tl.Size.Height = msg.Height
+	tl.Size.Weight = 1
+	tl.Rect = Rect{Width: msg.Width, Height: msg.Height}
+	return tl.relayout()
 }
 
-func (tl TileLayout) IsLayout() bool { return true }
+func (tl *TileLayout) IsLayout() bool { return true }
 
-func (tl TileLayout) Init() tea.Cmd { return nil }
+// Returns the errors of the last layout pass of the layout and all nested layouts,
+// or nil if all constraints were satisfied.
+func (tl *TileLayout) Err() error {
+	var errs []error
+	for layout := range tl.Layouts() {
+		if layout.err != nil {
+			errs = append(errs, layout.err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Initialize and mount (see Mounter) all tiles of the tree, batching their commands.
+// Tiles added to the tree afterwards are initialized and mounted when they are attached.
+func (tl *TileLayout) Init() tea.Cmd {
+	tl.initialized = true
+	var cmds []tea.Cmd
+	for _, tile := range tl.Tiles {
+		if tile != nil {
+			cmds = append(cmds, tile.Init(), mountHook(tile))
+		}
+	}
+	return tea.Batch(cmds...)
+}
 
 // Handle update messages from BubbleTea.
+// The tree change messages (AddTileMsg, RemoveTileMsg, SetSizeMsg, SetDirectionMsg), BroadcastMsg,
+// TargetedMsg and the emitted events (see Emit) are applied by the root.
+// The root layout also returns the commands queued by the changes of the tree since the last update.
 // On WidnowSizeMsg, the layout is "layouted" and LayoutUpdatedMsg is additionally returned.
-// The message is forwarded to each tile.
-func (tl TileLayout) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+// Only the tiles whose geometry changed receive the WindowSizeMsg and TileUpdatedMsg.
+// If a layout in the tree is unable to satisfy the constraints of its tiles, the root returns LayoutErrorMsg for it as well.
+// If no tile is focused yet, the first focusable tile receives the focus.
+// Mouse messages are delivered only to the tile under the pointer, unless a splitter is dragged.
+// Key messages are delivered only to the focused tile.
+// Other messages are forwarded to each tile, or only to the tiles declaring their type (see MsgConsumer).
+func (tl *TileLayout) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmds []tea.Cmd
 
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
-		tl.handleWindowSizeMsg(msg)
+		changed := tl.handleWindowSizeMsg(msg)
 		cmds = append(cmds, tl.layoutUpdated())
-		for i, tile := range tl.Tiles {
+		if tl.isRoot() {
+			for layout := range tl.Layouts() {
+				if layout.err != nil {
+					cmds = append(cmds, layout.layoutError(layout.err))
+				}
+			}
+		}
+		for _, c := range changed {
 			newMsg := tea.WindowSizeMsg{
-				Width:  tile.GetSize().Width,
-				Height: tile.GetSize().Height,
+				Width:  c.tile.GetSize().Width,
+				Height: c.tile.GetSize().Height,
 			}
-			updated, cmd := tile.Update(newMsg)
-			tl.Tiles[i] = updated.(Tile)
+			cmd, _ := tl.updateTile(c.tile, newMsg)
+			cmds = append(cmds, cmd)
+			cmds = append(cmds, NewTileUpdatedMsg(c.tile, c.old))
+		}
+		if tl.isRoot() && tl.Focused() == nil {
+			cmds = append(cmds, tl.FocusNext())
+		}
+	case tea.KeyMsg:
+		cmds = append(cmds, tl.handleKey(msg))
+	case tea.MouseMsg:
+		if cmd, handled := tl.handleSplitter(msg); handled {
 			cmds = append(cmds, cmd)
-			cmds = append(cmds, NewTileUpdatedMsg(tile))
+			break
 		}
+		cmds = append(cmds, tl.routeMouse(msg))
+	case AddTileMsg, RemoveTileMsg, SetSizeMsg, SetDirectionMsg:
+		cmds = append(cmds, tl.applyChange(msg))
+	case BroadcastMsg:
+		cmds = append(cmds, tl.broadcast(msg))
+	case TargetedMsg:
+		cmds = append(cmds, tl.target(msg))
+	case *Event:
+		cmds = append(cmds, tl.dispatch(msg))
 	case LayoutUpdatedMsg:
 		for i, tile := range tl.Tiles {
 			updated, ucmds := tile.Update(msg)
@@ -142,253 +252,149 @@ func (tl TileLayout) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 	case TileUpdatedMsg:
 		for i, tile := range tl.Tiles {
-			if tile.IsLayout() || tile.GetName() == msg.Name {
+			path := Path(tile)
+			_, nested := tile.(*TileLayout)
+			if path == msg.Path || nested && strings.HasPrefix(msg.Path, path+"/") {
 				updated, cmd := tile.Update(msg)
 				tl.Tiles[i] = updated.(Tile)
 				cmds = append(cmds, cmd)
 			}
 		}
 	default:
-		for i, tile := range tl.Tiles {
-			updated, cmd := tile.Update(msg)
-			tl.Tiles[i] = updated.(Tile)
-			cmds = append(cmds, cmd)
-		}
+		cmds = append(cmds, tl.forward(msg))
+	}
+	if tl.isRoot() {
+		cmds = append(cmds, tl.drainPending())
 	}
 	return tl, tea.Batch(cmds...)
 }
 
-// Render all tiles, joining them together.
-func (tl TileLayout) View() string {
-	if len(tl.Tiles) == 0 {
-		return ""
-	}
-	var views []string
-
-	for _, tile := range tl.Tiles {
-		if tile == nil {
-			continue
-		}
-		views = append(views, tile.View())
-	}
+// Render all tiles, placing their views by their rects, see compose.
+// The gaps, paddings and margins are rendered with the FillStyle.
+func (tl *TileLayout) View() string {
+	return tl.compose()
+}
 
-	if tl.Direction == Horizontal {
-		return lipgloss.JoinHorizontal(lipgloss.Top, views...)
-	}
-	return lipgloss.JoinVertical(lipgloss.Left, views...)
+// A tile whose geometry was changed by a layout pass, with its size before the change.
+type change struct {
+	tile Tile
+	old  Size
 }
 
 // Perform dimension calculation for all tiles in the layout.
-func (tl *TileLayout) layout() {
-	if len(tl.Tiles) == 0 {
-		return
-	}
-	totalHeight := 0
-	totalWidth := 0
+// The main axis, without the padding and the frame of the borders, the gaps and the margins, is shared between the tiles
+// by the solver (see distribute), while on the cross axis each tile takes the whole layout
+// (without the padding, the frame and its margins), respecting its min/max/fixed.
+// The tiles are placed one after another, separated by the gap and their margins.
+// With Borders, a line is placed in the middle of each gap.
+// The leftover space is distributed by Justify on the main axis and by Align on the cross axis.
+// Hidden tiles get an empty rect at their position and take no gap or margin.
+// Tiles whose constraints could not be satisfied are recorded in the layout error.
+// The Resizer and VisibilityAware hooks of the changed tiles are called.
+// Returns the tiles whose geometry changed.
+func (tl *TileLayout) layout() []change {
+	tl.err = nil
+	tl.separators = nil
+	var tiles []Tile
 	for _, tile := range tl.Tiles {
-		if tile == nil {
-			continue
-		}
-		newSize := tile.GetSize()
-		switch tl.Direction {
-		case Horizontal:
-			// for horizontal, the total height is always concidered to be the layout height
-			totalHeight = tl.Size.Height
-			// decide the actual height in case of min/max/fixed
-			tileHeight := decideHeight(newSize, tl)
-			// in case the calculated width is more than the left available
-			tileWidth := min(decideWidth(newSize, tl), tl.Size.Width-totalWidth)
-			// append to total
-			totalWidth += tileWidth
-			if tileWidth > 0 {
-				// set new size to tile
-				newSize.Width = tileWidth
-				newSize.Height = tileHeight
-				tile.SetSize(newSize)
-			}
-		case Vertical:
-			// for vertical, the total width is always concidered to be the layout width
-			totalWidth = tl.Size.Width
-			// in case the calculated height is more than the left available
-			tileHeight := min(decideHeight(newSize, tl), tl.Size.Height-totalHeight)
-			// decide the actual width in case of min/max/fixed
-			tileWidth := decideWidth(newSize, tl)
-			// append to total
-			totalHeight += tileHeight
-			if tileHeight > 0 {
-				// set new size to tile
-				newSize.Width = tileWidth
-				newSize.Height = tileHeight
-				tile.SetSize(newSize)
-			}
+		if tile != nil {
+			tiles = append(tiles, tile)
 		}
 	}
-	// distribute the leftover spaces caused by constraints and rounding errors
-	somethingResized := true
-	sanityCheck := 0
-	for somethingResized {
-		// we may need to run it multiple times - some tiles may hit their constraints and
-		// there would be still leftover space
-		sanityCheck++
-		totalWidth, totalHeight, somethingResized = tl.distributeLeftover(totalWidth, totalHeight)
-		// but more than 100 times is quite unusual. Panic out.
-		if sanityCheck > 100 {
-			panic("layout is unable to size itself for more than 100 iterations")
-		}
+	if len(tiles) == 0 {
+		return nil
 	}
-}
-
-// A tile can grow width when no fixed width is set and either no max width is set, or
-// the current width is less than the max width
-func canGrowWidth(t Tile) bool {
-	size := t.GetSize()
-	return size.FixedWidth == 0 && (size.MaxWidth == 0 || size.Width < size.MaxWidth)
-}
-
-// A tile can grow height when no fixed height is set and either no max height is set, or
-// the current height is less than the max height
-func canGrowHeight(t Tile) bool {
-	size := t.GetSize()
-	return size.FixedHeight == 0 && (size.MaxHeight == 0 || size.Height < size.MaxHeight)
-}
-
-// Distribute the leftover space. The sum weight of all growable tiles is calculated
-// and distributed between them. Maximum constraints are respected. In case there is
-// still leftover space, but no growable tiles, the dimensions are left as they are.
-func (tl *TileLayout) distributeLeftover(totalWidth, totalHeight int) (int, int, bool) {
-	leftoverWidth := tl.Size.Width - totalWidth
-	leftoverHeight := tl.Size.Height - totalHeight
-	if leftoverHeight == 0 && leftoverWidth == 0 {
-		return totalWidth, totalHeight, false
+	horizontal := tl.Direction == Horizontal
+	content := tl.Rect.Inset(tl.inset())
+	gap := tl.gap()
+	mainTotal, crossTotal := content.Height, content.Width
+	if horizontal {
+		mainTotal, crossTotal = content.Width, content.Height
 	}
-	somethingResized := false
-	sumGrowableWeight := 0.0
-	for _, tile := range tl.Tiles {
-		weight := tile.GetSize().Weight
-		switch tl.Direction {
-		case Horizontal:
-			if canGrowWidth(tile) {
-				sumGrowableWeight += weight
-			}
-		case Vertical:
-			if canGrowHeight(tile) {
-				sumGrowableWeight += weight
-			}
+	var spans []span
+	for _, tile := range tiles {
+		if size := tile.GetSize(); !size.Hidden {
+			spans = append(spans, axisSpan(size, horizontal))
+			before, after := size.Margin.along(horizontal)
+			mainTotal -= before + after
 		}
 	}
-
-	for _, tile := range tl.Tiles {
-		switch tl.Direction {
-		case Horizontal:
-			if canGrowWidth(tile) && leftoverWidth > 0 {
-				size := tile.GetSize()
-				normalizedWeight := size.Weight / sumGrowableWeight
-				toAdd := max(1, leftoverWidth*int(normalizedWeight))
-				if size.MaxWidth > 0 && size.Width+toAdd > size.MaxWidth {
-					toAdd = (size.MaxWidth - size.Width)
-				}
-				size.Width += toAdd
-				tile.SetSize(size)
-				somethingResized = true
-				totalWidth += toAdd
-				leftoverWidth -= toAdd
+	mainTotal -= gap * max(0, len(spans)-1)
+	shownSizes, _ := distribute(mainTotal, spans)
+	leftover := mainTotal
+	for _, size := range shownSizes {
+		leftover -= size
+	}
+	leftover = max(0, leftover)
+	var unsatisfied []string
+	var changed []change
+	offset, next, end := 0, 0, 0
+	for _, tile := range tiles {
+		size := tile.GetSize()
+		main, cross, crossOffset, before, after, extra := 0, 0, 0, 0, 0, 0
+		if !size.Hidden {
+			if next > 0 {
+				offset += gap
 			}
-		case Vertical:
-			if canGrowHeight(tile) && leftoverHeight > 0 {
-				size := tile.GetSize()
-				normalizedWeight := size.Weight / sumGrowableWeight
-				toAdd := max(1, leftoverHeight*int(normalizedWeight))
-				if size.MaxHeight > 0 && size.Height+toAdd > size.MaxHeight {
-					toAdd = (size.MaxHeight - size.Height)
-				}
-				size.Height += toAdd
-				tile.SetSize(size)
-				somethingResized = true
-				totalHeight += toAdd
-				leftoverHeight -= toAdd
+			var crossAfter int
+			var ok bool
+			before, after = size.Margin.along(horizontal)
+			crossOffset, crossAfter = size.Margin.along(!horizontal)
+			offset += before
+			main = shownSizes[next]
+			crossAvailable := max(0, crossTotal-crossOffset-crossAfter)
+			cross, ok = axisSpan(size, !horizontal).fit(crossAvailable)
+			if !ok || main < spans[next].min {
+				unsatisfied = append(unsatisfied, tile.GetName())
+			}
+			align := size.Align
+			if align == AlignAuto {
+				align = tl.Align
 			}
+			crossOffset += align.offset(max(0, crossAvailable-cross))
+			extra = tl.Justify.offset(leftover, next, len(spans))
+			next++
 		}
-	}
-	return totalWidth, totalHeight, somethingResized
-}
-
-// Decide the width of a tile in available space:
-// 1. If fixed is defined, the minimum between the fixed and available is returned.
-// 2. Total fixed width is subtracted from the available width.
-// 3. Width is calucalated based on the weight.
-// 4. If calculated is more than the max, max is returned.
-// 5. If calculated is less than the min, min is returned.
-// 6. If the layout is vertical, the available width would be used as default.
-// 6.1 If min is defined, the maximum between the min and available is returned.
-// 6.2 If max is defined, the minimum between the max and available is returned.
-// 7. If there are no constraint, the calculated (3.) width is returned.
-func decideWidth(s Size, layout *TileLayout) int {
-	availableWidth := layout.Size.Width
-	if s.FixedWidth > 0 {
-		return min(availableWidth, s.FixedWidth)
-	}
-	if layout.Direction == Horizontal {
-		availableWidth -= layout.TotalFixedWidth
-	}
-	w := availableWidth
-	if layout.Direction == Horizontal {
-		w = int(float64(availableWidth) * s.Weight)
-	}
-	if s.MaxWidth > 0 && w > s.MaxWidth {
-		return s.MaxWidth
-	}
-	if s.MinWidth > 0 && w < s.MinWidth {
-		return s.MinWidth
-	}
-
-	if layout.Direction == Vertical {
-		if s.MinWidth > 0 {
-			return max(availableWidth, s.MinWidth)
+		rect := Rect{X: content.X + crossOffset, Y: content.Y + offset + extra, Width: cross, Height: main}
+		if horizontal {
+			rect = Rect{X: content.X + offset + extra, Y: content.Y + crossOffset, Width: main, Height: cross}
+		}
+		offset += main + after
+		if !size.Hidden {
+			start, _ := along(rect, horizontal)
+			// the line goes through the middle of the gap from the end of the previous tile
+			if next > 1 && tl.Borders != nil {
+				tl.separators = append(tl.separators, end+(start-before-end-1)/2)
+			}
+			end = start + main + after
 		}
-		if s.MaxWidth > 0 {
-			return min(availableWidth, s.MaxWidth)
+		size.Width, size.Height = rect.Width, rect.Height
+		old := change{tile: tile, old: tile.GetSize()}
+		oldRect := tile.GetRect()
+		tile.SetSize(size)
+		tile.SetRect(rect)
+		if oldRect != rect {
+			changed = append(changed, old)
+			tl.enqueue(resizeHooks(tile, oldRect, rect))
 		}
-		return availableWidth
 	}
-	return w
+	if len(unsatisfied) > 0 {
+		tl.err = &LayoutError{Layout: tl.Name, Tiles: unsatisfied}
+	}
+	return changed
 }
 
-// Decide the height of a tile in available space:
-// 1. If fixed is defined, the minimum between the fixed and available is returned.
-// 2. Total fixed height is subtracted from the available height.
-// 3. Height is calucalated based on the weight.
-// 4. If calculated is more than the max, max is returned.
-// 5. If calculated is less than the min, min is returned.
-// 6. If the layout is horizontal, the available height would be used as default.
-// 6.1 If min is defined, the maximum between the min and available is returned.
-// 6.2 If max is defined, the minimum between the max and available is returned.
-// 7. If there are no constraint, the calculated (3.) height is returned.
-func decideHeight(s Size, layout *TileLayout) int {
-	availableHeight := layout.Size.Height
-	if s.FixedHeight > 0 {
-		return min(availableHeight, s.FixedHeight)
-	}
-	if layout.Direction == Vertical {
-		availableHeight -= layout.TotalFixedHeight
-	}
-	h := availableHeight
-	if layout.Direction == Vertical {
-		h = int(float64(availableHeight) * s.Weight)
-	}
-	if s.MaxHeight > 0 && h > s.MaxHeight {
-		return s.MaxHeight
-	}
-	if s.MinHeight > 0 && h < s.MinHeight {
-		return s.MinHeight
-	}
-	if layout.Direction == Horizontal {
-		if s.MinHeight > 0 {
-			return max(availableHeight, s.MinHeight)
-		}
-		if s.MaxHeight > 0 {
-			return min(availableHeight, s.MaxHeight)
+// Perform the layout of this layout and all nested layouts.
+// Every layout records the time of its pass, including its nested layouts, in its metrics.
+// Returns the tiles whose geometry changed, anywhere in the tree.
+func (tl *TileLayout) relayout() []change {
+	start := time.Now()
+	changed := tl.layout()
+	for _, tile := range tl.Tiles {
+		if layout, ok := tile.(*TileLayout); ok {
+			changed = append(changed, layout.relayout()...)
 		}
-		return availableHeight
 	}
-	return h
+	tl.Metrics.RenderTime = time.Since(start)
+	return changed
 }
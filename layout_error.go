@@ -0,0 +1,36 @@
+package tilelayout
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Error reported when a layout is unable to satisfy the constraints of some of its tiles,
+// e.g. the sum of the minimum sizes is more than the available space.
+// The layout still sizes itself on a best effort basis.
+type LayoutError struct {
+	Layout string
+	Tiles  []string
+}
+
+func (e *LayoutError) Error() string {
+	return fmt.Sprintf("layout %q is unable to satisfy the constraints of: %s", e.Layout, strings.Join(e.Tiles, ", "))
+}
+
+// Message returned when a layout is unable to satisfy the constraints of its tiles.
+type LayoutErrorMsg struct {
+	Name string
+	Err  *LayoutError
+}
+
+// The command to return the LayoutErrorMsg
+func (tl *TileLayout) layoutError(err *LayoutError) tea.Cmd {
+	return func() tea.Msg {
+		return LayoutErrorMsg{
+			Name: tl.Name,
+			Err:  err,
+		}
+	}
+}
@@ -0,0 +1,172 @@
+package tilelayout
+
+import (
+	"math"
+	"sort"
+)
+
+// The constraints of a tile along a single axis.
+// A max of 0 means the tile is unbounded.
+type span struct {
+	min    int
+	max    int
+	weight float64
+}
+
+// Build the span of a size along the width (horizontal) or the height.
+// A fixed dimension is treated as equal min and max.
+func axisSpan(s Size, horizontal bool) span {
+	sp := span{min: s.MinHeight, max: s.MaxHeight, weight: s.Weight}
+	fixed := s.FixedHeight
+	if horizontal {
+		sp.min, sp.max, fixed = s.MinWidth, s.MaxWidth, s.FixedWidth
+	}
+	if fixed > 0 {
+		sp.min, sp.max = fixed, fixed
+	}
+	sp.min = max(0, sp.min)
+	if sp.max > 0 && sp.max < sp.min {
+		sp.max = sp.min
+	}
+	sp.weight = max(0, sp.weight)
+	return sp
+}
+
+// Clamp a value to the span constraints.
+func (sp span) clamp(v float64) float64 {
+	if sp.max > 0 && v > float64(sp.max) {
+		v = float64(sp.max)
+	}
+	return max(v, float64(sp.min))
+}
+
+// Fit a tile in the available cross-axis space. The tile takes all of the available space
+// unless constrained by its min/max. The result never exceeds the available space.
+// Returns false if the min constraint could not be satisfied.
+func (sp span) fit(available int) (int, bool) {
+	size := int(sp.clamp(float64(available)))
+	if size > available {
+		return max(0, available), false
+	}
+	return size, true
+}
+
+// Distribute total cells between the spans on the main axis in a single pass:
+//  1. If the sum of the minimums exceeds the total, the total is shared proportionally
+//     to the minimums and false is returned.
+//  2. Otherwise the free space is shared by weight. Spans that would violate their min/max
+//     are frozen at the violated bound and the rest is shared again between the others.
+//     This repeats at most once per span.
+//  3. The fractional shares are rounded using the largest remainder method, so the
+//     result sums up exactly to the total. Of the shares with equal remainders, the cells
+//     go to the higher weights first and, with equal weights too, to the earlier spans.
+//
+// If all spans hit their max, the result may sum up to less than the total.
+// When no span has a weight, the free space is shared equally.
+func distribute(total int, spans []span) ([]int, bool) {
+	total = max(0, total)
+	sumMin := 0
+	for _, sp := range spans {
+		sumMin += sp.min
+	}
+	if sumMin > total {
+		shares := make([]float64, len(spans))
+		for i, sp := range spans {
+			shares[i] = float64(total) * float64(sp.min) / float64(sumMin)
+		}
+		return largestRemainder(total, shares, spans), false
+	}
+
+	shares := make([]float64, len(spans))
+	frozen := make([]bool, len(spans))
+	for range len(spans) + 1 {
+		free := float64(total)
+		weights := 0.0
+		active := 0
+		for i, sp := range spans {
+			if frozen[i] {
+				free -= shares[i]
+				continue
+			}
+			weights += sp.weight
+			active++
+		}
+		if active == 0 {
+			break
+		}
+		violation := 0.0
+		for i, sp := range spans {
+			if frozen[i] {
+				continue
+			}
+			if weights > 0 {
+				shares[i] = free * sp.weight / weights
+			} else {
+				shares[i] = free / float64(active)
+			}
+			violation += sp.clamp(shares[i]) - shares[i]
+		}
+		const epsilon = 1e-9
+		changed := false
+		for i, sp := range spans {
+			if frozen[i] {
+				continue
+			}
+			clamped := sp.clamp(shares[i])
+			diff := clamped - shares[i]
+			if math.Abs(diff) <= epsilon {
+				continue
+			}
+			// freeze only the violations in the direction of the total violation
+			// (or all of them, if they cancel each other out)
+			if violation > epsilon && diff < 0 || violation < -epsilon && diff > 0 {
+				continue
+			}
+			shares[i] = clamped
+			frozen[i] = true
+			changed = true
+		}
+		if !changed {
+			break
+		}
+	}
+	return largestRemainder(total, shares, spans), true
+}
+
+// Round the shares down and hand out the remaining cells one by one to the shares with
+// the largest fractional parts. Ties are broken by the higher weight, then by position.
+// A cell is never given to a span at its max.
+func largestRemainder(total int, shares []float64, spans []span) []int {
+	sizes := make([]int, len(shares))
+	order := make([]int, len(shares))
+	remaining := total
+	for i, share := range shares {
+		// protect against floating point errors like 4.9999999
+		sizes[i] = int(math.Floor(share + 1e-9))
+		remaining -= sizes[i]
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		fa := shares[order[a]] - float64(sizes[order[a]])
+		fb := shares[order[b]] - float64(sizes[order[b]])
+		if fa != fb {
+			return fa > fb
+		}
+		return spans[order[a]].weight > spans[order[b]].weight
+	})
+	for _, i := range order {
+		if remaining <= 0 {
+			break
+		}
+		if shares[i]-float64(sizes[i]) <= 1e-9 {
+			// only fractional shares are rounded up
+			continue
+		}
+		if spans[i].max > 0 && sizes[i] >= spans[i].max {
+			continue
+		}
+		sizes[i]++
+		remaining--
+	}
+	return sizes
+}
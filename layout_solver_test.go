@@ -0,0 +1,131 @@
+package tilelayout
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestDistribute(t *testing.T) {
+	tests := []struct {
+		name  string
+		total int
+		spans []span
+		want  []int
+		ok    bool
+	}{
+		{
+			name:  "weights",
+			total: 10,
+			spans: []span{{weight: 0.3}, {weight: 0.7}},
+			want:  []int{3, 7},
+			ok:    true,
+		},
+		{
+			name:  "three times 0.33 fills exactly",
+			total: 100,
+			spans: []span{{weight: 0.33}, {weight: 0.33}, {weight: 0.33}},
+			want:  []int{34, 33, 33},
+			ok:    true,
+		},
+		{
+			name:  "zero weights share equally",
+			total: 9,
+			spans: []span{{}, {}, {}},
+			want:  []int{3, 3, 3},
+			ok:    true,
+		},
+		{
+			name:  "zero weights with a remainder",
+			total: 10,
+			spans: []span{{}, {}, {}},
+			want:  []int{4, 3, 3},
+			ok:    true,
+		},
+		{
+			name:  "min is frozen and the rest is shared again",
+			total: 10,
+			spans: []span{{min: 6, weight: 0.1}, {weight: 0.9}},
+			want:  []int{6, 4},
+			ok:    true,
+		},
+		{
+			name:  "max is frozen and the rest is shared again",
+			total: 10,
+			spans: []span{{max: 2, weight: 0.5}, {weight: 0.5}},
+			want:  []int{2, 8},
+			ok:    true,
+		},
+		{
+			name:  "fixed",
+			total: 10,
+			spans: []span{axisSpan(Size{FixedWidth: 4}, true), {weight: 1}},
+			want:  []int{4, 6},
+			ok:    true,
+		},
+		{
+			name:  "every span at its max leaves space",
+			total: 20,
+			spans: []span{{max: 5, weight: 0.5}, {max: 3, weight: 0.5}},
+			want:  []int{5, 3},
+			ok:    true,
+		},
+		{
+			name:  "mins exceeding the total are shared proportionally",
+			total: 6,
+			spans: []span{{min: 6}, {min: 3}},
+			want:  []int{4, 2},
+			ok:    false,
+		},
+		{
+			name:  "equal mins exceeding the total",
+			total: 5,
+			spans: []span{{min: 10, weight: 1}, {min: 10}},
+			want:  []int{3, 2},
+			ok:    false,
+		},
+		{
+			name:  "no spans",
+			total: 10,
+			spans: nil,
+			want:  []int{},
+			ok:    true,
+		},
+		{
+			name:  "negative total",
+			total: -5,
+			spans: []span{{weight: 1}, {min: 2}},
+			want:  []int{0, 0},
+			ok:    false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := distribute(tt.total, tt.spans)
+			if !slices.Equal(got, tt.want) || ok != tt.ok {
+				t.Errorf("distribute(%d, %v) = %v, %v; want %v, %v", tt.total, tt.spans, got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+// The sizes must sum up to the total and respect the min/max whenever the constraints allow it,
+// whatever the order of the spans.
+func TestDistributeFillsExactly(t *testing.T) {
+	spans := []span{{min: 3, weight: 0.2}, {max: 7, weight: 0.5}, {weight: 0.1}, {min: 1, max: 4, weight: 0.2}}
+	for total := 8; total <= 60; total++ {
+		for shift := range spans {
+			rotated := append(slices.Clone(spans[shift:]), spans[:shift]...)
+			sizes, ok := distribute(total, rotated)
+			sum := 0
+			for i, size := range sizes {
+				sum += size
+				if size < rotated[i].min || rotated[i].max > 0 && size > rotated[i].max {
+					t.Errorf("total %d, spans %v: size %d violates %v", total, rotated, size, rotated[i])
+				}
+			}
+			if !ok || sum != total {
+				t.Errorf("total %d, spans %v: sizes %v sum up to %d, ok %v", total, rotated, sizes, sum, ok)
+			}
+		}
+	}
+}
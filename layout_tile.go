@@ -4,37 +4,69 @@ import tea "github.com/charmbracelet/bubbletea"
 
 type Tile interface {
 	tea.Model
+	GetID() ID
+	SetID(id ID)
 	GetName() string
 	GetSize() Size
 	SetSize(size Size)
+	GetRect() Rect
+	SetRect(rect Rect)
 	GetParent() Tile
 	SetParent(tile Tile)
 	IsLayout() bool
 }
 
 type BaseTile struct {
+	ID     ID
 	Name   string
 	Size   Size
+	Rect   Rect
 	Parent Tile
+	// Classes of the tile for the selectors, see Query.
+	Classes []string
 }
 
+// The computed geometry of a tile in absolute terminal coordinates.
+// Set by the parent layout on every layout pass.
+type Rect struct {
+	X      int
+	Y      int
+	Width  int
+	Height int
+}
+
+// Returns true if the rect has no cells, like the rect of a hidden tile.
+func (r Rect) Empty() bool {
+	return r.Width <= 0 || r.Height <= 0
+}
+
+// Message sent to a tile when its geometry was changed by the layout,
+// with the size before and after the change. The message is routed by the Path of the tile.
 type TileUpdatedMsg struct {
-	Name string
-	Size Size
+	Name    string
+	Path    string
+	OldSize Size
+	NewSize Size
 }
 
-func NewTileUpdatedMsg(t Tile) tea.Cmd {
-	return func() tea.Msg {
-		return TileUpdatedMsg{
-			Name: t.GetName(),
-			Size: t.GetSize(),
-		}
+// The command to return the TileUpdatedMsg for the tile, whose size was old before the change.
+func NewTileUpdatedMsg(t Tile, old Size) tea.Cmd {
+	msg := TileUpdatedMsg{
+		Name:    t.GetName(),
+		Path:    Path(t),
+		OldSize: old,
+		NewSize: t.GetSize(),
 	}
+	return func() tea.Msg { return msg }
 }
 
+func (bt BaseTile) GetID() ID              { return bt.ID }
+func (bt *BaseTile) SetID(id ID)           { bt.ID = id }
 func (bt BaseTile) GetName() string        { return bt.Name }
 func (bt BaseTile) GetSize() Size          { return bt.Size }
 func (bt *BaseTile) SetSize(size Size)     { bt.Size = size }
+func (bt BaseTile) GetRect() Rect          { return bt.Rect }
+func (bt *BaseTile) SetRect(rect Rect)     { bt.Rect = rect }
 func (bt BaseTile) GetParent() Tile        { return bt.Parent }
 func (bt *BaseTile) SetParent(parent Tile) { bt.Parent = parent }
 func (vt BaseTile) IsLayout() bool         { return false }
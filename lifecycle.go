@@ -0,0 +1,111 @@
+package tilelayout
+
+import tea "github.com/charmbracelet/bubbletea"
+
+// Tiles implementing Mounter are notified when they become part of a running tree:
+// on Init of the tree, or when they are attached to an already initialized tree (Add, InsertAt,
+// Replace, AddTileMsg). Mount is called after Init of the tile.
+type Mounter interface {
+	Mount() tea.Cmd
+}
+
+// Tiles implementing Unmounter are notified when they are removed from a running tree
+// (Remove, Replace, RemoveTileMsg), together with all tiles of a removed layout.
+// This is the place to release watchers, goroutines and other resources of the tile.
+type Unmounter interface {
+	Unmount()
+}
+
+// Tiles implementing Resizer are notified when the layout gives them a new rect.
+// The Size and the Rect of the tile are already updated when Resize is called.
+type Resizer interface {
+	Resize(old, new Rect) tea.Cmd
+}
+
+// Tiles implementing VisibilityAware are notified when they are hidden or shown:
+// a tile is visible when its rect is not empty, so it is hidden by Size.Hidden
+// or when there is no space left for it.
+type VisibilityAware interface {
+	VisibilityChanged(visible bool) tea.Cmd
+}
+
+// Hide or show the tile at the path (or with the name, see Find), in the layout or any nested layout.
+// A hidden tile takes no space and does not receive the focus.
+func (tl *TileLayout) SetHidden(path string, hidden bool) error {
+	parent, index, ok := tl.find(path)
+	if !ok {
+		return ErrTileNotFound
+	}
+	tile := parent.Tiles[index]
+	size := tile.GetSize()
+	if size.Hidden == hidden {
+		return nil
+	}
+	size.Hidden = hidden
+	tile.SetSize(size)
+	parent.updateFixed()
+	if hidden {
+		tl.blurHidden(tile)
+	}
+	parent.relayoutTiles()
+	return nil
+}
+
+// Clear the focus if the tile being hidden is the focused tile or a layout containing it.
+// The BlurMsg is returned by the next Update of the root layout.
+func (tl *TileLayout) blurHidden(tile Tile) {
+	root := tl.root()
+	if focused := root.Focused(); focused != nil && (sameTile(tile, focused) || isAncestor(tile, focused)) {
+		root.enqueue(root.Focus(nil))
+	}
+}
+
+// Returns true if the tile or any of its parent layouts is hidden.
+func isHidden(tile Tile) bool {
+	for t := tile; t != nil; t = t.GetParent() {
+		if t.GetSize().Hidden {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns true if the tile is a layout containing the other tile.
+func isAncestor(tile, other Tile) bool {
+	layout, ok := tile.(*TileLayout)
+	return ok && layout.pathTo(other) != nil
+}
+
+// Returns the Mount command of the tile, if it implements Mounter.
+func mountHook(tile Tile) tea.Cmd {
+	if m, ok := tile.(Mounter); ok {
+		return m.Mount()
+	}
+	return nil
+}
+
+// Unmount the tile and, for a layout, all tiles in its tree, the leaves first.
+func unmountHook(tile Tile) {
+	if layout, ok := tile.(*TileLayout); ok {
+		for _, child := range layout.Tiles {
+			if child != nil {
+				unmountHook(child)
+			}
+		}
+	}
+	if u, ok := tile.(Unmounter); ok {
+		u.Unmount()
+	}
+}
+
+// Call the Resizer and VisibilityAware hooks of the tile whose rect changed.
+func resizeHooks(tile Tile, old, new Rect) tea.Cmd {
+	var cmds []tea.Cmd
+	if r, ok := tile.(Resizer); ok {
+		cmds = append(cmds, r.Resize(old, new))
+	}
+	if v, ok := tile.(VisibilityAware); ok && old.Empty() != new.Empty() {
+		cmds = append(cmds, v.VisibilityChanged(!new.Empty()))
+	}
+	return tea.Batch(cmds...)
+}
@@ -0,0 +1,184 @@
+package tilelayout
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Message to append the tile to the layout at the path.
+//
+// The tree change messages can be returned as commands, e.g. from background workers,
+// and are applied by the Update of the root layout. The path is made of the tile names
+// separated by "/", relative to the root, e.g. "ContentArea/RightArea". The name of the
+// root may be included, and an empty path addresses the root itself. See Find.
+type AddTileMsg struct {
+	Path string
+	Tile Tile
+}
+
+// Message to remove the tile at the path.
+type RemoveTileMsg struct {
+	Path string
+}
+
+// Message to set the constraints of the tile at the path.
+// The computed Width and Height of the size are ignored. For a tile wrapped by Bordered,
+// the constraints are for the inside of the frame. Hiding the focused tile clears the focus, like SetHidden.
+type SetSizeMsg struct {
+	Path string
+	Size Size
+}
+
+// Message to set the direction of the layout at the path.
+type SetDirectionMsg struct {
+	Path      string
+	Direction Direction
+}
+
+// Message returned when a tree change message (or BroadcastMsg) could not be applied.
+type ChangeErrorMsg struct {
+	Msg tea.Msg
+	Err error
+}
+
+// Apply a tree change message. The changed layouts are layouted again and only the tiles
+// whose geometry changed are notified, with the commands queued for the end of the update.
+func (tl *TileLayout) applyChange(msg tea.Msg) tea.Cmd {
+	var err error
+	switch msg := msg.(type) {
+	case AddTileMsg:
+		err = tl.addAt(msg.Path, msg.Tile)
+	case RemoveTileMsg:
+		err = tl.removeAt(msg.Path)
+	case SetSizeMsg:
+		err = tl.setSizeAt(msg.Path, msg.Size)
+	case SetDirectionMsg:
+		err = tl.setDirectionAt(msg.Path, msg.Direction)
+	}
+	if err != nil {
+		return func() tea.Msg {
+			return ChangeErrorMsg{Msg: msg, Err: err}
+		}
+	}
+	return nil
+}
+
+func (tl *TileLayout) addAt(path string, tile Tile) error {
+	layout, err := tl.layoutAt(path)
+	if err != nil {
+		return err
+	}
+	return layout.InsertAt(len(layout.Tiles), tile)
+}
+
+func (tl *TileLayout) removeAt(path string) error {
+	_, parent, index, ok := tl.resolve(path)
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrTileNotFound, path)
+	}
+	if parent == nil {
+		return fmt.Errorf("root cannot be removed: %s", path)
+	}
+	tl.removeFrom(parent, index)
+	return nil
+}
+
+func (tl *TileLayout) setSizeAt(path string, size Size) error {
+	tile, parent, _, ok := tl.resolve(path)
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrTileNotFound, path)
+	}
+	// the constraints of a tile wrapped by Bordered are for the inside of the frame
+	tile = unwrap(tile)
+	current := tile.GetSize()
+	size.Width, size.Height = current.Width, current.Height
+	tile.SetSize(size)
+	if parent == nil {
+		// the root is sized by the window
+		return nil
+	}
+	parent.updateFixed()
+	if size.Hidden && !current.Hidden {
+		tl.blurHidden(tile)
+	}
+	parent.relayoutTiles()
+	return nil
+}
+
+func (tl *TileLayout) setDirectionAt(path string, direction Direction) error {
+	layout, err := tl.layoutAt(path)
+	if err != nil {
+		return err
+	}
+	layout.Direction = direction
+	layout.relayoutTiles()
+	return nil
+}
+
+// Resolve the path to a layout.
+func (tl *TileLayout) layoutAt(path string) (*TileLayout, error) {
+	tile, _, _, ok := tl.resolve(path)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrTileNotFound, path)
+	}
+	layout, ok := tile.(*TileLayout)
+	if !ok {
+		return nil, fmt.Errorf("not a layout: %s", path)
+	}
+	return layout, nil
+}
+
+// Resolve the path, relative to the layout, to a tile (see Find). Returns the tile, its parent
+// layout and its index in the parent. For an empty path (or the name of the layout),
+// the layout itself is returned with no parent.
+func (tl *TileLayout) resolve(path string) (Tile, *TileLayout, int, bool) {
+	path = strings.Trim(path, "/")
+	if path == "" {
+		return tl, nil, 0, true
+	}
+	segments := strings.Split(path, "/")
+	if segments[0] == tl.Name && !tl.hasChild(segments[0]) {
+		return tl.resolve(strings.Join(segments[1:], "/"))
+	}
+	if tile, parent, index, ok := tl.resolveExact(segments); ok {
+		return tile, parent, index, true
+	}
+	return tl.findNested(segments)
+}
+
+// Resolve the names, each name being a direct child of the previous one.
+func (tl *TileLayout) resolveExact(segments []string) (Tile, *TileLayout, int, bool) {
+	current := tl
+	for i, segment := range segments {
+		index := current.childIndex(segment)
+		if index < 0 {
+			return nil, nil, 0, false
+		}
+		tile := current.Tiles[index]
+		if i == len(segments)-1 {
+			return tile, current, index, true
+		}
+		layout, ok := tile.(*TileLayout)
+		if !ok {
+			return nil, nil, 0, false
+		}
+		current = layout
+	}
+	return nil, nil, 0, false
+}
+
+// Returns the index of the direct child with the name, or -1.
+func (tl *TileLayout) childIndex(name string) int {
+	for i, tile := range tl.Tiles {
+		if tile != nil && tile.GetName() == name {
+			return i
+		}
+	}
+	return -1
+}
+
+func (tl *TileLayout) hasChild(name string) bool {
+	return tl.childIndex(name) >= 0
+}
@@ -0,0 +1,112 @@
+package tilelayout
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Apply the tree change message and return the error of its ChangeErrorMsg, if any.
+func applyMsg(root *TileLayout, msg tea.Msg) error {
+	_, cmd := root.Update(msg)
+	var errs []error
+	var collect func(tea.Cmd)
+	collect = func(cmd tea.Cmd) {
+		if cmd == nil {
+			return
+		}
+		switch msg := cmd().(type) {
+		case tea.BatchMsg:
+			for _, cmd := range msg {
+				collect(cmd)
+			}
+		case ChangeErrorMsg:
+			errs = append(errs, msg.Err)
+		}
+	}
+	collect(cmd)
+	return errors.Join(errs...)
+}
+
+func TestAddTileMsg(t *testing.T) {
+	root, _, right := newMutateTree()
+	tile := newTestTile("x", Size{FixedHeight: 1})
+	if err := applyMsg(root, AddTileMsg{Path: "right", Tile: tile}); err != nil {
+		t.Fatalf("AddTileMsg failed: %v", err)
+	}
+	if got := tileNames(right); !slices.Equal(got, []string{"c", "x"}) {
+		t.Errorf("tiles = %v; want [c x]", got)
+	}
+	if tile.GetParent() != right || tile.GetRect() != (Rect{X: 10, Y: 2, Width: 10, Height: 1}) {
+		t.Errorf("parent %v, rect %+v; want right, below c", tile.GetParent(), tile.GetRect())
+	}
+	if err := applyMsg(root, AddTileMsg{Path: "right/c", Tile: newTestTile("y", Size{})}); err == nil {
+		t.Errorf("AddTileMsg to a leaf tile succeeded")
+	}
+	if err := applyMsg(root, AddTileMsg{Path: "nothing", Tile: newTestTile("y", Size{})}); !errors.Is(err, ErrTileNotFound) {
+		t.Errorf("AddTileMsg to a missing layout = %v; want ErrTileNotFound", err)
+	}
+}
+
+func TestRemoveTileMsg(t *testing.T) {
+	root, left, _ := newMutateTree()
+	if err := applyMsg(root, RemoveTileMsg{Path: "left/a"}); err != nil {
+		t.Fatalf("RemoveTileMsg failed: %v", err)
+	}
+	if got := tileNames(left); !slices.Equal(got, []string{"b"}) || left.TotalFixedHeight != 0 {
+		t.Errorf("tiles = %v, TotalFixedHeight %d; want [b], 0", got, left.TotalFixedHeight)
+	}
+	if err := applyMsg(root, RemoveTileMsg{Path: "left/a"}); !errors.Is(err, ErrTileNotFound) {
+		t.Errorf("RemoveTileMsg of a removed tile = %v; want ErrTileNotFound", err)
+	}
+	if err := applyMsg(root, RemoveTileMsg{Path: ""}); err == nil {
+		t.Errorf("RemoveTileMsg of the root succeeded")
+	}
+}
+
+func TestSetSizeMsg(t *testing.T) {
+	root, left, _ := newMutateTree()
+	if err := applyMsg(root, SetSizeMsg{Path: "left/a", Size: Size{FixedHeight: 3}}); err != nil {
+		t.Fatalf("SetSizeMsg failed: %v", err)
+	}
+	if left.TotalFixedHeight != 3 {
+		t.Errorf("TotalFixedHeight = %d; want 3", left.TotalFixedHeight)
+	}
+	if rect := left.Tiles[1].GetRect(); rect.Y != 3 || rect.Height != 1 {
+		t.Errorf("b rect = %+v; want the last row", rect)
+	}
+	if err := applyMsg(root, SetSizeMsg{Path: "nothing"}); !errors.Is(err, ErrTileNotFound) {
+		t.Errorf("SetSizeMsg of a missing tile = %v; want ErrTileNotFound", err)
+	}
+}
+
+func TestSetDirectionMsg(t *testing.T) {
+	root, left, _ := newMutateTree()
+	if err := applyMsg(root, SetDirectionMsg{Path: "left", Direction: Horizontal}); err != nil {
+		t.Fatalf("SetDirectionMsg failed: %v", err)
+	}
+	if left.Direction != Horizontal {
+		t.Errorf("Direction = %v; want Horizontal", left.Direction)
+	}
+	// a has no weight and gets no width, b takes the whole layout
+	if rect := left.Tiles[1].GetRect(); rect != (Rect{X: 0, Y: 0, Width: 10, Height: 4}) {
+		t.Errorf("b rect = %+v; want the whole layout", rect)
+	}
+	if err := applyMsg(root, SetDirectionMsg{Path: "left/a", Direction: Horizontal}); err == nil {
+		t.Errorf("SetDirectionMsg of a leaf tile succeeded")
+	}
+}
+
+func TestSetSizeMsgHidesFocus(t *testing.T) {
+	root, _, _ := newMutateTree()
+	b, _ := root.Find("left/b")
+	root.Focus(b)
+	if err := applyMsg(root, SetSizeMsg{Path: "left/b", Size: Size{Weight: 1, Hidden: true}}); err != nil {
+		t.Fatalf("SetSizeMsg failed: %v", err)
+	}
+	if focused := root.Focused(); focused != nil {
+		t.Errorf("Focused() = %q; want nil after hiding the focused tile", focused.GetName())
+	}
+}
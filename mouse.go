@@ -0,0 +1,46 @@
+package tilelayout
+
+import tea "github.com/charmbracelet/bubbletea"
+
+// Tiles implementing MouseObserver receive every mouse event when ObservesAllMouse returns true,
+// not only the ones over the tile. The coordinates are still relative to the tile,
+// so they may be negative or exceed its size.
+type MouseObserver interface {
+	ObservesAllMouse() bool
+}
+
+// Returns true if the point is inside the rect.
+func (r Rect) Contains(x, y int) bool {
+	return x >= r.X && x < r.X+r.Width && y >= r.Y && y < r.Y+r.Height
+}
+
+// Route the mouse event to the tile under the pointer. The event is passed down the nested
+// layouts and delivered only to the (deepest) tile containing the pointer, with coordinates
+// relative to the tile. Mouse observers receive the event regardless of the pointer position.
+func (tl *TileLayout) routeMouse(msg tea.MouseMsg) tea.Cmd {
+	var cmds []tea.Cmd
+	for i, tile := range tl.Tiles {
+		if tile == nil {
+			continue
+		}
+		if _, ok := tile.(*TileLayout); ok {
+			// nested layouts route the absolute event further down
+			updated, cmd := tile.Update(msg)
+			tl.Tiles[i] = updated.(Tile)
+			cmds = append(cmds, cmd)
+			continue
+		}
+		rect := tile.GetRect()
+		observer, ok := tile.(MouseObserver)
+		if !rect.Contains(msg.X, msg.Y) && !(ok && observer.ObservesAllMouse()) {
+			continue
+		}
+		local := msg
+		local.X -= rect.X
+		local.Y -= rect.Y
+		updated, cmd := tile.Update(local)
+		tl.Tiles[i] = updated.(Tile)
+		cmds = append(cmds, cmd)
+	}
+	return tea.Batch(cmds...)
+}
@@ -0,0 +1,212 @@
+package tilelayout
+
+import (
+	"errors"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+var (
+	ErrTileNotFound    = errors.New("tile not found")
+	ErrIndexOutOfRange = errors.New("index out of range")
+	ErrInvalidMove     = errors.New("layout cannot be moved into itself")
+	ErrDuplicateName   = errors.New("layout already has a tile with the name")
+)
+
+// Remove the tile at the path (or with the name, see Find) from the layout or any nested layout.
+// The parent of the removed tile is cleared and the layout is layouted again.
+// Returns the removed tile.
+func (tl *TileLayout) Remove(path string) (Tile, error) {
+	parent, index, ok := tl.find(path)
+	if !ok {
+		return nil, ErrTileNotFound
+	}
+	return tl.removeFrom(parent, index), nil
+}
+
+// Insert the tile at the index of the layout, moving the tiles from the index on.
+// The index may be equal to the number of tiles to append the tile.
+// The parent of the tile is set to the layout and the layout is layouted again.
+// If the tree was already initialized, the tile is initialized as well,
+// the commands are returned by Cmd or the next Update of the root layout.
+func (tl *TileLayout) InsertAt(index int, tile Tile) error {
+	if index < 0 || index > len(tl.Tiles) {
+		return ErrIndexOutOfRange
+	}
+	if err := tl.checkName(tile.GetName(), nil); err != nil {
+		return err
+	}
+	tl.insert(index, tile)
+	tl.mount(tile)
+	tl.relayoutTiles()
+	return nil
+}
+
+// Replace the tile at the path (or with the name, see Find), in the layout or any nested layout,
+// with the tile. The tile takes the position and the parent of the replaced tile and is initialized,
+// if the tree was already initialized (see Cmd). Returns the replaced tile.
+func (tl *TileLayout) Replace(path string, tile Tile) (Tile, error) {
+	parent, index, ok := tl.find(path)
+	if !ok {
+		return nil, ErrTileNotFound
+	}
+	if err := parent.checkName(tile.GetName(), parent.Tiles[index]); err != nil {
+		return nil, err
+	}
+	old := parent.detach(index)
+	tl.forget(old)
+	tl.unmount(old)
+	parent.insert(index, tile)
+	parent.mount(tile)
+	parent.relayoutTiles()
+	return old, nil
+}
+
+// Move the tile at the path (or with the name, see Find), from the layout or any nested layout,
+// to the index of the new parent. Both the old and the new parent are layouted again.
+func (tl *TileLayout) Move(path string, newParent *TileLayout, index int) error {
+	parent, from, ok := tl.find(path)
+	if !ok {
+		return ErrTileNotFound
+	}
+	tile := parent.Tiles[from]
+	if layout, ok := tile.(*TileLayout); ok && (layout == newParent || layout.pathTo(newParent) != nil) {
+		return ErrInvalidMove
+	}
+	limit := len(newParent.Tiles)
+	if parent == newParent {
+		limit--
+	}
+	if index < 0 || index > limit {
+		return ErrIndexOutOfRange
+	}
+	if err := newParent.checkName(tile.GetName(), tile); err != nil {
+		return err
+	}
+	parent.detach(from)
+	newParent.insert(index, tile)
+	parent.relayoutTiles()
+	if parent != newParent {
+		newParent.relayoutTiles()
+	}
+	return nil
+}
+
+// Remove the tile at the index of the parent, which is in the tree of the layout.
+func (tl *TileLayout) removeFrom(parent *TileLayout, index int) Tile {
+	tile := parent.detach(index)
+	tl.forget(tile)
+	tl.unmount(tile)
+	parent.relayoutTiles()
+	return tile
+}
+
+// Find the tile at the path (see Find) in the layout or any nested layout.
+// Returns the parent layout of the tile and the index of the tile in it.
+func (tl *TileLayout) find(path string) (*TileLayout, int, bool) {
+	_, parent, index, ok := tl.resolve(path)
+	if !ok || parent == nil {
+		return nil, 0, false
+	}
+	return parent, index, true
+}
+
+// Prepare the tile to be added to the layout: the parent is set to the layout
+// and the tile gets an ID, if it has none yet.
+func (tl *TileLayout) attach(tile Tile) Tile {
+	assignID(tile)
+	tile.SetParent(tl)
+	return tile
+}
+
+// Initialize and mount (see Mounter) the newly attached tile, if the tree was already initialized.
+// The commands are returned by the next Update of the root layout.
+func (tl *TileLayout) mount(tile Tile) {
+	if tl.root().initialized {
+		tl.enqueue(tile.Init())
+		tl.enqueue(mountHook(tile))
+	}
+}
+
+// Unmount (see Unmounter) the removed tile, if the tree was already initialized.
+func (tl *TileLayout) unmount(tile Tile) {
+	if tl.root().initialized {
+		unmountHook(tile)
+	}
+}
+
+// Insert the tile at the index and update the fixed totals and the consumed message types.
+// A dragged splitter is released, as the tiles next to it may have changed.
+func (tl *TileLayout) insert(index int, tile Tile) {
+	tl.root().drag = nil
+	tl.Tiles = append(tl.Tiles[:index], append([]Tile{tl.attach(tile)}, tl.Tiles[index:]...)...)
+	tl.updateFixed()
+	tl.resetConsumed()
+}
+
+// Remove the tile at the index, clear its parent and update the fixed totals
+// and the consumed message types. A dragged splitter is released, like by insert.
+func (tl *TileLayout) detach(index int) Tile {
+	tl.root().drag = nil
+	tile := tl.Tiles[index]
+	tl.Tiles = append(tl.Tiles[:index:index], tl.Tiles[index+1:]...)
+	tile.SetParent(nil)
+	tl.updateFixed()
+	tl.resetConsumed()
+	return tile
+}
+
+// Forget the focus of the removed tile, without sending BlurMsg,
+// as the tile is no longer in the tree.
+func (tl *TileLayout) forget(tile Tile) {
+	root := tl.root()
+	if root.focused != 0 && (root.focused == tile.GetID() || root.Focused() == nil) {
+		root.focused = 0
+	}
+}
+
+// Recalculate the total fixed width and height of the visible tiles.
+func (tl *TileLayout) updateFixed() {
+	tl.TotalFixedWidth, tl.TotalFixedHeight = 0, 0
+	for _, tile := range tl.Tiles {
+		if tile != nil && !tile.GetSize().Hidden {
+			tl.TotalFixedWidth += tile.GetSize().FixedWidth
+			tl.TotalFixedHeight += tile.GetSize().FixedHeight
+		}
+	}
+}
+
+// Layout the tiles again after a change of the tree, if the layout was already layouted.
+// The changed tiles are notified with the next Update of the root layout.
+func (tl *TileLayout) relayoutTiles() {
+	if tl.Rect.Width == 0 && tl.Rect.Height == 0 {
+		return
+	}
+	tl.enqueue(notifyChanged(tl.relayout()))
+}
+
+// Queue the command to be returned by the next Update of the root layout.
+func (tl *TileLayout) enqueue(cmd tea.Cmd) {
+	if cmd != nil {
+		tl.pending = append(tl.pending, cmd)
+	}
+}
+
+// Returns the commands queued by the changes of the tree since the last Update of the root layout:
+// the Init and Mount commands of the added tiles, the hooks and the TileUpdatedMsgs of the tiles
+// moved by the change. The root layout returns them from its next Update, so a tree changed
+// outside of it (e.g. in the Update of the model holding the layout) must return Cmd,
+// otherwise the commands wait until the next message arrives.
+func (tl *TileLayout) Cmd() tea.Cmd {
+	return tl.root().drainPending()
+}
+
+// Collect and clear the queued commands of the layout and all nested layouts.
+func (tl *TileLayout) drainPending() tea.Cmd {
+	var cmds []tea.Cmd
+	for layout := range tl.Layouts() {
+		cmds = append(cmds, layout.pending...)
+		layout.pending = nil
+	}
+	return tea.Batch(cmds...)
+}
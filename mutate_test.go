@@ -0,0 +1,189 @@
+package tilelayout
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Build a horizontal root with the layouts "left" (tiles a, b) and "right" (tile c),
+// laid out in 20x4.
+func newMutateTree() (root, left, right *TileLayout) {
+	root = NewRoot(Horizontal)
+	left = NewTileLayout("left", Vertical, Size{Weight: 0.5})
+	right = NewTileLayout("right", Vertical, Size{Weight: 0.5})
+	root.Add(left)
+	root.Add(right)
+	left.Add(newTestTile("a", Size{FixedHeight: 1}))
+	left.Add(newTestTile("b", Size{Weight: 1}))
+	right.Add(newTestTile("c", Size{FixedHeight: 2}))
+	root.Update(tea.WindowSizeMsg{Width: 20, Height: 4})
+	return root, left, right
+}
+
+func tileNames(layout *TileLayout) []string {
+	return names(layout.Tiles)
+}
+
+func TestRemove(t *testing.T) {
+	root, left, _ := newMutateTree()
+	removed, err := root.Remove("left/a")
+	if err != nil {
+		t.Fatalf("Remove failed: %v", err)
+	}
+	if removed.GetName() != "a" || removed.GetParent() != nil {
+		t.Errorf("removed %q with parent %v; want a without a parent", removed.GetName(), removed.GetParent())
+	}
+	if got := tileNames(left); !slices.Equal(got, []string{"b"}) {
+		t.Errorf("tiles = %v; want [b]", got)
+	}
+	if left.TotalFixedHeight != 0 {
+		t.Errorf("TotalFixedHeight = %d; want 0", left.TotalFixedHeight)
+	}
+	if rect := left.Tiles[0].GetRect(); rect.Height != 4 {
+		t.Errorf("b is %d high; want the whole layout", rect.Height)
+	}
+	if _, err := root.Remove("left/a"); !errors.Is(err, ErrTileNotFound) {
+		t.Errorf("Remove of a removed tile = %v; want ErrTileNotFound", err)
+	}
+}
+
+func TestInsertAt(t *testing.T) {
+	root, left, _ := newMutateTree()
+	tile := newTestTile("x", Size{FixedHeight: 2})
+	if err := left.InsertAt(1, tile); err != nil {
+		t.Fatalf("InsertAt failed: %v", err)
+	}
+	if got := tileNames(left); !slices.Equal(got, []string{"a", "x", "b"}) {
+		t.Errorf("tiles = %v; want [a x b]", got)
+	}
+	if tile.GetParent() != left || tile.GetID() == 0 {
+		t.Errorf("parent %v, ID %d; want left and an ID", tile.GetParent(), tile.GetID())
+	}
+	if left.TotalFixedHeight != 3 {
+		t.Errorf("TotalFixedHeight = %d; want 3", left.TotalFixedHeight)
+	}
+	if rect := tile.GetRect(); rect != (Rect{X: 0, Y: 1, Width: 10, Height: 2}) {
+		t.Errorf("rect = %+v; want the rows 1-2", rect)
+	}
+	for _, index := range []int{-1, 4} {
+		if err := left.InsertAt(index, newTestTile("y", Size{})); !errors.Is(err, ErrIndexOutOfRange) {
+			t.Errorf("InsertAt(%d) = %v; want ErrIndexOutOfRange", index, err)
+		}
+	}
+	if err := left.InsertAt(0, newTestTile("a", Size{})); !errors.Is(err, ErrDuplicateName) {
+		t.Errorf("InsertAt of a duplicate name = %v; want ErrDuplicateName", err)
+	}
+	if _, ok := root.Find("left/y"); ok {
+		t.Errorf("rejected tile was inserted")
+	}
+}
+
+func TestReplace(t *testing.T) {
+	root, left, _ := newMutateTree()
+	tile := newTestTile("x", Size{Weight: 1})
+	old, err := root.Replace("left/a", tile)
+	if err != nil {
+		t.Fatalf("Replace failed: %v", err)
+	}
+	if old.GetName() != "a" || old.GetParent() != nil {
+		t.Errorf("replaced %q with parent %v; want a without a parent", old.GetName(), old.GetParent())
+	}
+	if got := tileNames(left); !slices.Equal(got, []string{"x", "b"}) {
+		t.Errorf("tiles = %v; want [x b]", got)
+	}
+	if tile.GetParent() != left || left.TotalFixedHeight != 0 {
+		t.Errorf("parent %v, TotalFixedHeight %d; want left and 0", tile.GetParent(), left.TotalFixedHeight)
+	}
+	if _, err := root.Replace("left/x", newTestTile("b", Size{})); !errors.Is(err, ErrDuplicateName) {
+		t.Errorf("Replace with a duplicate name = %v; want ErrDuplicateName", err)
+	}
+	if _, err := root.Replace("left/b", newTestTile("b", Size{})); err != nil {
+		t.Errorf("Replace with the same name = %v; want nil", err)
+	}
+}
+
+func TestMove(t *testing.T) {
+	root, left, right := newMutateTree()
+	a, _ := root.Find("left/a")
+	if err := root.Move("left/a", right, 1); err != nil {
+		t.Fatalf("Move failed: %v", err)
+	}
+	if got := tileNames(left); !slices.Equal(got, []string{"b"}) {
+		t.Errorf("left tiles = %v; want [b]", got)
+	}
+	if got := tileNames(right); !slices.Equal(got, []string{"c", "a"}) {
+		t.Errorf("right tiles = %v; want [c a]", got)
+	}
+	if a.GetParent() != right {
+		t.Errorf("parent = %v; want right", a.GetParent())
+	}
+	if left.TotalFixedHeight != 0 || right.TotalFixedHeight != 3 {
+		t.Errorf("TotalFixedHeight = %d, %d; want 0, 3", left.TotalFixedHeight, right.TotalFixedHeight)
+	}
+	// both parents are layouted again
+	if rect := left.Tiles[0].GetRect(); rect.Height != 4 {
+		t.Errorf("b is %d high; want the whole layout", rect.Height)
+	}
+	if rect := a.GetRect(); rect != (Rect{X: 10, Y: 2, Width: 10, Height: 1}) {
+		t.Errorf("a rect = %+v; want below c", rect)
+	}
+}
+
+func TestMoveErrors(t *testing.T) {
+	root, left, right := newMutateTree()
+	inner := NewTileLayout("inner", Vertical, Size{})
+	left.Add(inner)
+	if err := root.Move("left", inner, 0); !errors.Is(err, ErrInvalidMove) {
+		t.Errorf("Move into its own subtree = %v; want ErrInvalidMove", err)
+	}
+	if err := root.Move("left", left, 0); !errors.Is(err, ErrInvalidMove) {
+		t.Errorf("Move into itself = %v; want ErrInvalidMove", err)
+	}
+	if err := root.Move("left/a", right, 2); !errors.Is(err, ErrIndexOutOfRange) {
+		t.Errorf("Move past the end = %v; want ErrIndexOutOfRange", err)
+	}
+	if err := root.Move("left/a", left, 3); !errors.Is(err, ErrIndexOutOfRange) {
+		t.Errorf("Move past the end of the same parent = %v; want ErrIndexOutOfRange", err)
+	}
+	if err := root.Move("nothing", right, 0); !errors.Is(err, ErrTileNotFound) {
+		t.Errorf("Move of a missing tile = %v; want ErrTileNotFound", err)
+	}
+	if got := tileNames(left); !slices.Equal(got, []string{"a", "b", "inner"}) {
+		t.Errorf("tiles after the failed moves = %v; want [a b inner]", got)
+	}
+}
+
+func TestMutationsReleaseDrag(t *testing.T) {
+	mutations := map[string]func(root, left, right *TileLayout) error{
+		"InsertAt": func(root, left, right *TileLayout) error { return left.InsertAt(0, newTestTile("x", Size{})) },
+		"Remove": func(root, left, right *TileLayout) error {
+			_, err := root.Remove("left/a")
+			return err
+		},
+		"Replace": func(root, left, right *TileLayout) error {
+			_, err := root.Replace("left/a", newTestTile("x", Size{}))
+			return err
+		},
+		"Move": func(root, left, right *TileLayout) error { return root.Move("left/a", right, 0) },
+	}
+	for name, mutate := range mutations {
+		t.Run(name, func(t *testing.T) {
+			root, left, right := newMutateTree()
+			root.Splitters = true
+			// press on the last column of left
+			root.Update(tea.MouseMsg{X: 9, Y: 0, Action: tea.MouseActionPress, Button: tea.MouseButtonLeft})
+			if root.drag == nil {
+				t.Fatalf("the splitter is not dragged")
+			}
+			if err := mutate(root, left, right); err != nil {
+				t.Fatalf("%s failed: %v", name, err)
+			}
+			if root.drag != nil {
+				t.Errorf("the splitter is still dragged after %s", name)
+			}
+		})
+	}
+}
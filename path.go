@@ -0,0 +1,95 @@
+package tilelayout
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+)
+
+// Unique identifier of a tile, assigned automatically when the tile is attached to a layout.
+// The ID is kept when the tile is moved, so it can be used to address the tile
+// even if its name or path changes.
+type ID uint64
+
+var lastID atomic.Uint64
+
+// Returns a new unique ID.
+func newID() ID {
+	return ID(lastID.Add(1))
+}
+
+// Assign a new ID to the tile, unless it already has one.
+func assignID(tile Tile) {
+	if tile.GetID() == 0 {
+		tile.SetID(newID())
+	}
+}
+
+// Returns the path of the tile: the names of the tile and all its parent layouts,
+// starting at the root and separated by "/", e.g. "Root/ContentArea/RightArea/Box3".
+func Path(tile Tile) string {
+	var names []string
+	for t := tile; t != nil; t = t.GetParent() {
+		names = append(names, t.GetName())
+	}
+	for i, j := 0, len(names)-1; i < j; i, j = i+1, j-1 {
+		names[i], names[j] = names[j], names[i]
+	}
+	return strings.Join(names, "/")
+}
+
+// Find the tile at the path, relative to the layout. The name of the layout may be included,
+// and an empty path addresses the layout itself. If the path does not match exactly, each name
+// may match any nested tile of the previous one, so "ContentArea/Box3" finds
+// "Root/ContentArea/RightArea/Box3" and "Box3" finds the first Box3 in the tree.
+func (tl *TileLayout) Find(path string) (Tile, bool) {
+	tile, _, _, ok := tl.resolve(path)
+	return tile, ok
+}
+
+// Find the tile with the ID in the layout (including the layout itself) or any nested layout.
+func (tl *TileLayout) FindID(id ID) (Tile, bool) {
+	for tile := range tl.All() {
+		if tile.GetID() == id {
+			return tile, true
+		}
+	}
+	return nil, false
+}
+
+// Find the tile matching the names, each name being a nested tile of the previous one.
+// The direct children are preferred over the deeper tiles.
+// Returns the tile, its parent layout and its index in the parent.
+func (tl *TileLayout) findNested(names []string) (Tile, *TileLayout, int, bool) {
+	for i, tile := range tl.Tiles {
+		if tile == nil || tile.GetName() != names[0] {
+			continue
+		}
+		if len(names) == 1 {
+			return tile, tl, i, true
+		}
+		if layout, ok := tile.(*TileLayout); ok {
+			if found, parent, index, ok := layout.findNested(names[1:]); ok {
+				return found, parent, index, true
+			}
+		}
+	}
+	for _, tile := range tl.Tiles {
+		if layout, ok := tile.(*TileLayout); ok {
+			if found, parent, index, ok := layout.findNested(names); ok {
+				return found, parent, index, true
+			}
+		}
+	}
+	return nil, nil, 0, false
+}
+
+// Returns ErrDuplicateName if another tile of the layout than the excluded one has the name.
+func (tl *TileLayout) checkName(name string, exclude Tile) error {
+	for _, tile := range tl.Tiles {
+		if tile != nil && tile != exclude && tile.GetName() == name {
+			return fmt.Errorf("%w: %s", ErrDuplicateName, name)
+		}
+	}
+	return nil
+}
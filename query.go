@@ -0,0 +1,279 @@
+package tilelayout
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+var ErrInvalidSelector = errors.New("invalid selector")
+
+// Message to deliver the message to every leaf tile matching the selector (see Query).
+// Applied by the Update of the root layout. If the selector is invalid, ChangeErrorMsg is returned.
+type BroadcastMsg struct {
+	Selector string
+	Msg      tea.Msg
+}
+
+// Tiles implementing Classed can be selected by their classes, e.g. ".log".
+// BaseTile implements it with its Classes.
+type Classed interface {
+	HasClass(class string) bool
+}
+
+// Returns true if the tile has the class.
+func (bt BaseTile) HasClass(class string) bool {
+	return slices.Contains(bt.Classes, class)
+}
+
+// Add the classes to the tile, skipping the ones it already has.
+func (bt *BaseTile) AddClass(classes ...string) {
+	for _, class := range classes {
+		if !bt.HasClass(class) {
+			bt.Classes = append(bt.Classes, class)
+		}
+	}
+}
+
+// Remove the class from the tile.
+func (bt *BaseTile) RemoveClass(class string) {
+	bt.Classes = slices.DeleteFunc(bt.Classes, func(c string) bool { return c == class })
+}
+
+// Returns the tiles in the tree of the layout (not including the layout itself) matching
+// the selector, in tree order. Like in CSS, a selector is made of compound selectors joined
+// by a space (any nested tile) or ">" (direct child), and several selectors can be separated
+// by commas. A compound selector combines:
+//   - a name glob like "Box*" (see path.Match), or "*" for any tile
+//   - "Horizontal" or "Vertical", matching the layouts with the direction (or the tiles with the name)
+//   - ".class", see Classed
+//   - "#id", the ID of the tile
+//   - ":type(Name)", the Go type of the tile, e.g. ":type(ViewportTile)" or ":type(tiles.ViewportTile)"
+//
+// For example "Vertical > *", "ContentArea Box*" or ".log, :type(LogTile)".
+func (tl *TileLayout) Query(selector string) ([]Tile, error) {
+	selectors, err := parseSelector(selector)
+	if err != nil {
+		return nil, err
+	}
+	var matches []Tile
+	for tile := range tl.All() {
+		if tile == Tile(tl) {
+			continue
+		}
+		for _, sel := range selectors {
+			if tl.matches(tile, sel, len(sel.parts)-1) {
+				matches = append(matches, tile)
+				break
+			}
+		}
+	}
+	return matches, nil
+}
+
+// Deliver the message to the leaf tiles matching the selector. Matching layouts are skipped,
+// as they would forward the message to all their tiles.
+func (tl *TileLayout) broadcast(msg BroadcastMsg) tea.Cmd {
+	matches, err := tl.Query(msg.Selector)
+	if err != nil {
+		return func() tea.Msg {
+			return ChangeErrorMsg{Msg: msg, Err: err}
+		}
+	}
+	var cmds []tea.Cmd
+	for _, tile := range matches {
+		if _, ok := tile.(*TileLayout); ok {
+			continue
+		}
+		cmd, _ := tl.updateTile(tile, msg.Msg)
+		cmds = append(cmds, cmd)
+	}
+	return tea.Batch(cmds...)
+}
+
+// A selector: compound selectors joined by combinators, combinators[i] joining parts[i] and parts[i+1].
+type selector struct {
+	parts       []compound
+	combinators []byte
+}
+
+// A compound selector, all its conditions must match.
+type compound struct {
+	name    string
+	classes []string
+	ids     []ID
+	types   []string
+}
+
+// Returns true if the tile matches the selector up to the part at the index,
+// looking for the ancestors up to the layout.
+func (tl *TileLayout) matches(tile Tile, sel selector, index int) bool {
+	if !sel.parts[index].matches(tile) {
+		return false
+	}
+	if index == 0 {
+		return true
+	}
+	for parent := range tl.Ancestors(tile) {
+		if tl.matches(parent, sel, index-1) {
+			return true
+		}
+		if sel.combinators[index-1] == '>' {
+			return false
+		}
+	}
+	return false
+}
+
+func (c compound) matches(tile Tile) bool {
+	if c.name != "" && c.name != "*" {
+		matched, _ := path.Match(c.name, tile.GetName())
+		if layout, ok := tile.(*TileLayout); ok {
+			matched = matched ||
+				c.name == "Horizontal" && layout.Direction == Horizontal ||
+				c.name == "Vertical" && layout.Direction == Vertical
+		}
+		if !matched {
+			return false
+		}
+	}
+	for _, class := range c.classes {
+		if classed, ok := tile.(Classed); !ok || !classed.HasClass(class) {
+			return false
+		}
+	}
+	for _, id := range c.ids {
+		if tile.GetID() != id {
+			return false
+		}
+	}
+	for _, name := range c.types {
+		if !isType(tile, name) {
+			return false
+		}
+	}
+	return true
+}
+
+// Returns true if the Go type of the tile has the name, with or without the package name.
+// Pointers are ignored. A tile wrapped by Bordered matches both its own type and BorderedTile.
+func isType(tile Tile, name string) bool {
+	if inner := unwrap(tile); inner != tile && isType(inner, name) {
+		return true
+	}
+	t := reflect.TypeOf(tile)
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if strings.Contains(name, ".") {
+		return t.String() == name
+	}
+	return t.Name() == name
+}
+
+// Parse the comma separated selectors.
+func parseSelector(s string) ([]selector, error) {
+	var selectors []selector
+	for _, group := range strings.Split(s, ",") {
+		sel, err := parseGroup(group)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q: %v", ErrInvalidSelector, s, err)
+		}
+		selectors = append(selectors, sel)
+	}
+	return selectors, nil
+}
+
+// Parse a selector made of compound selectors and combinators.
+func parseGroup(s string) (selector, error) {
+	var sel selector
+	combinator := byte(0)
+	for i := 0; i < len(s); {
+		switch s[i] {
+		case ' ', '\t', '\n':
+			if len(sel.parts) > 0 && combinator == 0 {
+				combinator = ' '
+			}
+			i++
+			continue
+		case '>':
+			if len(sel.parts) == 0 || combinator == '>' {
+				return sel, errors.New("misplaced >")
+			}
+			combinator = '>'
+			i++
+			continue
+		}
+		c, n, err := parseCompound(s[i:])
+		if err != nil {
+			return sel, err
+		}
+		if len(sel.parts) > 0 {
+			sel.combinators = append(sel.combinators, combinator)
+		}
+		sel.parts = append(sel.parts, c)
+		combinator = 0
+		i += n
+	}
+	if len(sel.parts) == 0 {
+		return sel, errors.New("empty selector")
+	}
+	if combinator == '>' {
+		return sel, errors.New("misplaced >")
+	}
+	return sel, nil
+}
+
+// Parse the compound selector at the start of the string.
+// Returns the selector and the number of bytes parsed.
+func parseCompound(s string) (compound, int, error) {
+	var c compound
+	i := 0
+	token := func() string {
+		start := i
+		for i < len(s) && !strings.ContainsRune(" \t\n>.#:", rune(s[i])) {
+			i++
+		}
+		return s[start:i]
+	}
+	if name := token(); name != "" {
+		if _, err := path.Match(name, ""); err != nil {
+			return c, 0, err
+		}
+		c.name = name
+	}
+	for i < len(s) {
+		switch s[i] {
+		case '.':
+			i++
+			class := token()
+			if class == "" {
+				return c, 0, errors.New("missing class")
+			}
+			c.classes = append(c.classes, class)
+		case '#':
+			i++
+			id, err := strconv.ParseUint(token(), 10, 64)
+			if err != nil {
+				return c, 0, errors.New("invalid id")
+			}
+			c.ids = append(c.ids, ID(id))
+		case ':':
+			end := strings.IndexByte(s[i:], ')')
+			if !strings.HasPrefix(s[i:], ":type(") || end < 0 {
+				return c, 0, errors.New("unknown pseudo selector, only :type(Name) is supported")
+			}
+			c.types = append(c.types, s[i+len(":type("):i+end])
+			i += end + 1
+		default:
+			return c, i, nil
+		}
+	}
+	return c, i, nil
+}
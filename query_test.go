@@ -0,0 +1,119 @@
+package tilelayout
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// A leaf tile rendering nothing, for the tests.
+type testTile struct {
+	*BaseTile
+}
+
+func newTestTile(name string, size Size, classes ...string) *testTile {
+	return &testTile{BaseTile: &BaseTile{Name: name, Size: size, Classes: classes}}
+}
+
+func (t *testTile) Init() tea.Cmd                       { return nil }
+func (t *testTile) Update(tea.Msg) (tea.Model, tea.Cmd) { return t, nil }
+func (t *testTile) View() string                        { return "" }
+
+func TestParseSelector(t *testing.T) {
+	tests := []struct {
+		selector string
+		want     []selector
+	}{
+		{"Box*", []selector{{parts: []compound{{name: "Box*"}}}}},
+		{"*.log.warn", []selector{{parts: []compound{{name: "*", classes: []string{"log", "warn"}}}}}},
+		{"#12:type(testTile)", []selector{{parts: []compound{{ids: []ID{12}, types: []string{"testTile"}}}}}},
+		{"Vertical > *", []selector{{
+			parts:       []compound{{name: "Vertical"}, {name: "*"}},
+			combinators: []byte{'>'},
+		}}},
+		{"A B>C", []selector{{
+			parts:       []compound{{name: "A"}, {name: "B"}, {name: "C"}},
+			combinators: []byte{' ', '>'},
+		}}},
+		{" .a ,  B ", []selector{
+			{parts: []compound{{classes: []string{"a"}}}},
+			{parts: []compound{{name: "B"}}},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.selector, func(t *testing.T) {
+			got, err := parseSelector(tt.selector)
+			if err != nil {
+				t.Fatalf("parseSelector(%q) failed: %v", tt.selector, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseSelector(%q) = %+v; want %+v", tt.selector, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseSelectorErrors(t *testing.T) {
+	for _, selector := range []string{"", "A,", "> A", "A >", "A > > B", "A.", "#x", "#", ":hover", ":type(A", "[a"} {
+		t.Run(selector, func(t *testing.T) {
+			if _, err := parseSelector(selector); !errors.Is(err, ErrInvalidSelector) {
+				t.Errorf("parseSelector(%q) error = %v; want ErrInvalidSelector", selector, err)
+			}
+		})
+	}
+}
+
+func TestQuery(t *testing.T) {
+	root := NewRoot(Vertical)
+	content := NewTileLayout("Content", Horizontal, Size{Weight: 1})
+	side := NewTileLayout("Side", Vertical, Size{Weight: 0.3})
+	box1 := newTestTile("Box1", Size{Weight: 0.5}, "log")
+	box2 := newTestTile("Box2", Size{Weight: 0.5})
+	box3 := newTestTile("Box3", Size{Weight: 0.7}, "log", "warn")
+	status := newTestTile("Status", Size{FixedHeight: 1})
+	root.Add(content)
+	root.Add(status)
+	content.Add(side)
+	content.Add(box3)
+	side.Add(box1)
+	side.Add(box2)
+
+	tests := []struct {
+		selector string
+		want     []Tile
+	}{
+		{"Box*", []Tile{box1, box2, box3}},
+		{".log", []Tile{box1, box3}},
+		{".log.warn", []Tile{box3}},
+		{"Vertical", []Tile{side}},
+		{"Vertical > *", []Tile{content, box1, box2, status}},
+		{"Content > Box*", []Tile{box3}},
+		{"Content Box*", []Tile{box1, box2, box3}},
+		{"Horizontal Vertical .log", []Tile{box1}},
+		{":type(testTile)", []Tile{box1, box2, box3, status}},
+		{":type(tilelayout.TileLayout)", []Tile{content, side}},
+		{"Status, .warn", []Tile{box3, status}},
+		{"Nothing", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.selector, func(t *testing.T) {
+			got, err := root.Query(tt.selector)
+			if err != nil {
+				t.Fatalf("Query(%q) failed: %v", tt.selector, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Query(%q) = %v; want %v", tt.selector, names(got), names(tt.want))
+			}
+		})
+	}
+}
+
+func names(tiles []Tile) []string {
+	var names []string
+	for _, tile := range tiles {
+		names = append(names, tile.GetName())
+	}
+	return names
+}
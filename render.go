@@ -0,0 +1,114 @@
+package tilelayout
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// A tile whose view did not match the size of its rect, see TileLayout.Debug.
+type ViewMismatch struct {
+	Path string
+	// The size of the rect of the tile.
+	Width  int
+	Height int
+	// The size of the view: the widest line and the number of lines.
+	ViewWidth  int
+	ViewHeight int
+}
+
+// Returns the tiles whose views did not match their size in the last View of the layout
+// and all nested layouts. Only collected if Debug is enabled on the root layout.
+func (tl *TileLayout) Mismatches() []ViewMismatch {
+	var mismatches []ViewMismatch
+	for layout := range tl.Layouts() {
+		mismatches = append(mismatches, layout.mismatches...)
+	}
+	return mismatches
+}
+
+// Record the tile, if its view does not match the size of its rect.
+func (tl *TileLayout) checkView(tile Tile, lines []string) {
+	width := 0
+	for _, line := range lines {
+		width = max(width, ansi.StringWidth(line))
+	}
+	rect := tile.GetRect()
+	if width != rect.Width || len(lines) != rect.Height {
+		tl.mismatches = append(tl.mismatches, ViewMismatch{
+			Path:       Path(tile),
+			Width:      rect.Width,
+			Height:     rect.Height,
+			ViewWidth:  width,
+			ViewHeight: len(lines),
+		})
+	}
+}
+
+// A tile view placed on the canvas of a layout, relative to the layout.
+type placed struct {
+	rect  Rect
+	lines []string
+}
+
+// Compose the views of the tiles by their rects into the rect of the layout.
+// The output is exactly as wide and high as the layout: the views of the tiles are normalized
+// to their rects, clipping the overflowing cells (keeping the ANSI sequences and not splitting
+// wide characters) and padding the missing ones, and the cells not covered by any tile
+// are filled with the FillStyle, or the lines and the titles of the Borders.
+func (tl *TileLayout) compose() string {
+	debug := tl.root().Debug
+	tl.mismatches = nil
+	if tl.framed() {
+		// drawn before the nested layouts are composed, as they share the borders
+		tl.borders = tl.drawBorders()
+	}
+	var views []placed
+	for _, tile := range tl.Tiles {
+		if tile == nil || tile.GetRect().Empty() {
+			continue
+		}
+		lines := strings.Split(tile.View(), "\n")
+		if debug {
+			tl.checkView(tile, lines)
+		}
+		rect := tile.GetRect()
+		rect.X -= tl.Rect.X
+		rect.Y -= tl.Rect.Y
+		views = append(views, placed{rect: rect, lines: lines})
+	}
+	slices.SortStableFunc(views, func(a, b placed) int { return a.rect.X - b.rect.X })
+	rows := make([]string, tl.Rect.Height)
+	for y := range rows {
+		var sb strings.Builder
+		x := 0
+		for _, view := range views {
+			if y < view.rect.Y || y >= view.rect.Y+view.rect.Height || view.rect.X < x {
+				continue
+			}
+			sb.WriteString(tl.fillAt(x, y, view.rect.X-x))
+			line := ""
+			if y-view.rect.Y < len(view.lines) {
+				line = view.lines[y-view.rect.Y]
+			}
+			if ansi.StringWidth(line) > view.rect.Width {
+				line = ansi.Truncate(line, view.rect.Width, "")
+			}
+			sb.WriteString(line)
+			sb.WriteString(tl.fill(view.rect.Width - ansi.StringWidth(line)))
+			x = view.rect.X + view.rect.Width
+		}
+		sb.WriteString(tl.fillAt(x, y, tl.Rect.Width-x))
+		rows[y] = sb.String()
+	}
+	return strings.Join(rows, "\n")
+}
+
+// Returns the number of cells rendered with the FillStyle.
+func (tl *TileLayout) fill(cells int) string {
+	if cells <= 0 {
+		return ""
+	}
+	return tl.FillStyle.Render(strings.Repeat(" ", cells))
+}
@@ -0,0 +1,81 @@
+package tilelayout
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// A leaf tile rendering a fixed view, for the tests.
+type viewTile struct {
+	*BaseTile
+	view string
+}
+
+func newViewTile(name, view string) *viewTile {
+	return &viewTile{BaseTile: &BaseTile{Name: name, Size: Size{Weight: 1}}, view: view}
+}
+
+func (t *viewTile) Init() tea.Cmd                       { return nil }
+func (t *viewTile) Update(tea.Msg) (tea.Model, tea.Cmd) { return t, nil }
+func (t *viewTile) View() string                        { return t.view }
+
+func TestComposeNormalizesViews(t *testing.T) {
+	tests := []struct {
+		name          string
+		view          string
+		width, height int
+		want          string
+	}{
+		{"over-wide line with ANSI codes is clipped", "\x1b[31mabcdef\x1b[0m", 4, 1, "abcd"},
+		{"wide character at the edge is cut and its cell padded", "ab世", 3, 1, "ab "},
+		{"short lines are padded", "a\nbc", 3, 2, "a  \nbc "},
+		{"missing lines are padded", "a", 2, 3, "a \n  \n  "},
+		{"extra lines are dropped", "a\nb\nc", 1, 2, "a\nb"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := NewRoot(Horizontal)
+			root.Add(newViewTile("v", tt.view))
+			if got := render(root, tt.width, tt.height); got != tt.want {
+				t.Errorf("View() = %q; want %q", got, tt.want)
+			}
+			for _, line := range strings.Split(root.View(), "\n") {
+				if width := ansi.StringWidth(line); width != tt.width {
+					t.Errorf("line %q is %d cells wide; want %d", line, width, tt.width)
+				}
+			}
+		})
+	}
+}
+
+func TestComposeKeepsANSICodes(t *testing.T) {
+	root := NewRoot(Horizontal)
+	root.Add(newViewTile("v", "\x1b[31mabcdef\x1b[0m"))
+	render(root, 4, 1)
+	if view := root.View(); !strings.Contains(view, "\x1b[31mabcd") {
+		t.Errorf("View() = %q; want the color of the clipped line kept", view)
+	}
+}
+
+func TestMismatches(t *testing.T) {
+	tile := newViewTile("v", "abcdef")
+	root := NewRoot(Horizontal)
+	root.Add(tile)
+	root.Add(newViewTile("fits", "ab\ncd"))
+
+	render(root, 4, 2)
+	if got := root.Mismatches(); got != nil {
+		t.Errorf("Mismatches() without Debug = %+v; want none", got)
+	}
+
+	root.Debug = true
+	render(root, 4, 2)
+	want := []ViewMismatch{{Path: Path(tile), Width: 2, Height: 2, ViewWidth: 6, ViewHeight: 1}}
+	if got := root.Mismatches(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Mismatches() = %+v; want %+v", got, want)
+	}
+}
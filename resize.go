@@ -0,0 +1,159 @@
+package tilelayout
+
+import (
+	"math"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Grow (or shrink, if negative) the focused tile by the number of cells along the direction
+// of its parent layout. The space is taken from (or given to) the flexible siblings
+// proportionally to their sizes. The min/max constraints of the tile and its siblings are
+// respected and the fixed siblings are not affected. The change is done through the weights,
+// keeping their sum, so it is preserved on the next resize of the window.
+func (tl *TileLayout) ResizeFocused(cells int) tea.Cmd {
+	parent, index, ok := tl.focusedParent()
+	if !ok {
+		return nil
+	}
+	return parent.resizeTile(index, cells)
+}
+
+// Grow (or shrink, if negative) the focused tile by the weight step, relative to the weights
+// of its flexible siblings. See ResizeFocused.
+func (tl *TileLayout) ResizeFocusedWeight(step float64) tea.Cmd {
+	parent, index, ok := tl.focusedParent()
+	if !ok {
+		return nil
+	}
+	horizontal := parent.Direction == Horizontal
+	weights, total := 0.0, 0
+	for _, tile := range parent.Tiles {
+		if tile != nil && !isFixed(tile, horizontal) {
+			weights += tile.GetSize().Weight
+			_, length := along(tile.GetRect(), horizontal)
+			total += length
+		}
+	}
+	if weights <= 0 {
+		return nil
+	}
+	cells := int(math.Round(step / weights * float64(total)))
+	return parent.resizeTile(index, cells)
+}
+
+// Give the same weight to the focused tile and all its flexible siblings, keeping the sum
+// of their weights. Like Ctrl-W = in vim, the min/max constraints still apply.
+func (tl *TileLayout) EqualizeFocused() tea.Cmd {
+	parent, _, ok := tl.focusedParent()
+	if !ok {
+		return nil
+	}
+	horizontal := parent.Direction == Horizontal
+	var flexible []Tile
+	weights := 0.0
+	for _, tile := range parent.Tiles {
+		if tile != nil && !isFixed(tile, horizontal) {
+			flexible = append(flexible, tile)
+			weights += tile.GetSize().Weight
+		}
+	}
+	if len(flexible) == 0 {
+		return nil
+	}
+	if weights <= 0 {
+		weights = 1
+	}
+	for _, tile := range flexible {
+		size := tile.GetSize()
+		size.Weight = weights / float64(len(flexible))
+		tile.SetSize(size)
+	}
+	return notifyChanged(parent.relayout())
+}
+
+// Returns the parent layout of the focused tile and the index of the tile in it.
+func (tl *TileLayout) focusedParent() (*TileLayout, int, bool) {
+	focused := tl.Focused()
+	if focused == nil {
+		return nil, 0, false
+	}
+	path := tl.pathTo(focused)
+	if path == nil {
+		return nil, 0, false
+	}
+	parent := path[len(path)-1]
+	for i, tile := range parent.Tiles {
+		if sameTile(tile, focused) {
+			return parent, i, true
+		}
+	}
+	return nil, 0, false
+}
+
+// Resize the tile at the index by the number of cells, see ResizeFocused.
+func (tl *TileLayout) resizeTile(index, cells int) tea.Cmd {
+	horizontal := tl.Direction == Horizontal
+	target := tl.Tiles[index]
+	if isFixed(target, horizontal) || cells == 0 {
+		return nil
+	}
+	var others []Tile
+	var spans []span
+	flexTotal, weights := 0, 0.0
+	minOthers, maxOthers, bounded := 0, 0, true
+	for i, tile := range tl.Tiles {
+		if tile == nil || isFixed(tile, horizontal) {
+			continue
+		}
+		_, length := along(tile.GetRect(), horizontal)
+		flexTotal += length
+		weights += tile.GetSize().Weight
+		if i == index {
+			continue
+		}
+		// the siblings keep their proportions
+		sp := axisSpan(tile.GetSize(), horizontal)
+		sp.weight = float64(length)
+		others = append(others, tile)
+		spans = append(spans, sp)
+		minOthers += sp.min
+		maxOthers += sp.max
+		bounded = bounded && sp.max > 0
+	}
+	if weights <= 0 || flexTotal == 0 {
+		return nil
+	}
+	_, current := along(target.GetRect(), horizontal)
+	targetSpan := axisSpan(target.GetSize(), horizontal)
+	length := max(current+cells, targetSpan.min, 0)
+	if targetSpan.max > 0 {
+		length = min(length, targetSpan.max)
+	}
+	length = min(length, flexTotal-minOthers)
+	if bounded && len(others) > 0 {
+		length = max(length, flexTotal-maxOthers)
+	}
+	if length == current {
+		return nil
+	}
+	lengths, _ := distribute(flexTotal-length, spans)
+	size := target.GetSize()
+	size.Weight = weights * float64(length) / float64(flexTotal)
+	target.SetSize(size)
+	for i, tile := range others {
+		size := tile.GetSize()
+		size.Weight = weights * float64(lengths[i]) / float64(flexTotal)
+		tile.SetSize(size)
+	}
+	return notifyChanged(tl.relayout())
+}
+
+// Returns the TileUpdatedMsg commands for the changed tiles.
+func notifyChanged(changed []change) tea.Cmd {
+	var cmds []tea.Cmd
+	for _, c := range changed {
+		cmds = append(cmds, NewTileUpdatedMsg(c.tile, c.old))
+	}
+	return tea.Batch(cmds...)
+}
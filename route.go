@@ -0,0 +1,112 @@
+package tilelayout
+
+import (
+	"fmt"
+	"reflect"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Message to deliver the message only to the tile at the path (see Find).
+// Applied by the Update of the root layout, which routes it straight to the tile.
+// A layout at the path handles the message like any other, so it can be used to scope
+// a message to a branch of the tree. If there is no tile at the path, ChangeErrorMsg is returned.
+type TargetedMsg struct {
+	Path string
+	Msg  tea.Msg
+}
+
+// Tiles implementing MsgConsumer declare the types of the messages they consume, as sample
+// values, e.g. []tea.Msg{LogLineMsg{}}. A message of a declared type is delivered only to the
+// tiles declaring it, routed down the branches containing them, instead of being forwarded
+// to every tile. Messages of the other types are still forwarded to every tile.
+// The declarations are read again when the tree changes.
+type MsgConsumer interface {
+	ConsumedMsgs() []tea.Msg
+}
+
+// Deliver the message to the tile at the path.
+func (tl *TileLayout) target(msg TargetedMsg) tea.Cmd {
+	tile, parent, index, ok := tl.resolve(msg.Path)
+	if !ok {
+		return func() tea.Msg {
+			return ChangeErrorMsg{Msg: msg, Err: fmt.Errorf("%w: %s", ErrTileNotFound, msg.Path)}
+		}
+	}
+	if parent == nil {
+		_, cmd := tl.Update(msg.Msg)
+		return cmd
+	}
+	updated, cmd := tile.Update(msg.Msg)
+	parent.Tiles[index] = updated.(Tile)
+	return cmd
+}
+
+// Forward the message to the tiles. If a tile in the tree of the layout declared its type
+// (see MsgConsumer), the message is delivered only to the declaring tiles.
+func (tl *TileLayout) forward(msg tea.Msg) tea.Cmd {
+	msgType := reflect.TypeOf(msg)
+	routed := tl.consumedTypes()[msgType]
+	var cmds []tea.Cmd
+	for i, tile := range tl.Tiles {
+		if tile == nil {
+			continue
+		}
+		if routed && !consumes(tile, msgType) {
+			continue
+		}
+		updated, cmd := tile.Update(msg)
+		tl.Tiles[i] = updated.(Tile)
+		cmds = append(cmds, cmd)
+	}
+	return tea.Batch(cmds...)
+}
+
+// Returns true if the tile, or any tile in its tree for a layout, declared the message type.
+func consumes(tile Tile, msgType reflect.Type) bool {
+	if layout, ok := tile.(*TileLayout); ok {
+		return layout.consumedTypes()[msgType]
+	}
+	consumer, ok := tile.(MsgConsumer)
+	if !ok {
+		return false
+	}
+	for _, msg := range consumer.ConsumedMsgs() {
+		if reflect.TypeOf(msg) == msgType {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns the message types declared by the tiles in the tree of the layout.
+// The types are collected on the first use after a change of the tree.
+func (tl *TileLayout) consumedTypes() map[reflect.Type]bool {
+	if tl.consumed != nil {
+		return tl.consumed
+	}
+	tl.consumed = make(map[reflect.Type]bool)
+	for _, tile := range tl.Tiles {
+		switch tile := tile.(type) {
+		case *TileLayout:
+			for msgType := range tile.consumedTypes() {
+				tl.consumed[msgType] = true
+			}
+		case MsgConsumer:
+			for _, msg := range tile.ConsumedMsgs() {
+				if msg != nil {
+					tl.consumed[reflect.TypeOf(msg)] = true
+				}
+			}
+		}
+	}
+	return tl.consumed
+}
+
+// Forget the collected message types of the layout and all its parent layouts,
+// after a change of its tiles.
+func (tl *TileLayout) resetConsumed() {
+	for layout := tl; layout != nil; layout, _ = layout.GetParent().(*TileLayout) {
+		layout.consumed = nil
+	}
+}
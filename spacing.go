@@ -0,0 +1,43 @@
+package tilelayout
+
+// Space around the sides of a rect, in cells.
+type Spacing struct {
+	Top    int
+	Right  int
+	Bottom int
+	Left   int
+}
+
+// Creates new Spacing from 1 to 4 values, like in CSS: all sides; vertical and horizontal;
+// top, horizontal and bottom; or top, right, bottom and left.
+func NewSpacing(values ...int) Spacing {
+	switch len(values) {
+	case 1:
+		return Spacing{values[0], values[0], values[0], values[0]}
+	case 2:
+		return Spacing{values[0], values[1], values[0], values[1]}
+	case 3:
+		return Spacing{values[0], values[1], values[2], values[1]}
+	case 4:
+		return Spacing{values[0], values[1], values[2], values[3]}
+	}
+	return Spacing{}
+}
+
+// The space before and after the rect along the width (horizontal) or the height.
+func (s Spacing) along(horizontal bool) (int, int) {
+	if horizontal {
+		return s.Left, s.Right
+	}
+	return s.Top, s.Bottom
+}
+
+// Returns the rect reduced by the spacing on each side. The size does not go below zero.
+func (r Rect) Inset(s Spacing) Rect {
+	return Rect{
+		X:      r.X + s.Left,
+		Y:      r.Y + s.Top,
+		Width:  max(0, r.Width-s.Left-s.Right),
+		Height: max(0, r.Height-s.Top-s.Bottom),
+	}
+}
@@ -0,0 +1,140 @@
+package tilelayout
+
+import tea "github.com/charmbracelet/bubbletea"
+
+// Message returned when a splitter was dragged and the weights of the two tiles
+// next to it were changed.
+type SplitterMovedMsg struct {
+	Layout  string
+	Tiles   [2]string
+	Weights [2]float64
+}
+
+// A splitter being dragged, between the tiles at index and index+1 of the layout.
+// The offset is the distance of the pressed cell from the last cell of the first tile.
+type splitterDrag struct {
+	layout *TileLayout
+	index  int
+	offset int
+}
+
+// Handle the mouse message if it belongs to a splitter: a left press on the edge between
+// two tiles of a layout with Splitters enabled starts dragging, the motion moves the splitter
+// and the release stops dragging. Returns false if the message should be routed to the tiles.
+func (tl *TileLayout) handleSplitter(msg tea.MouseMsg) (tea.Cmd, bool) {
+	if tl.drag == nil {
+		if msg.Action != tea.MouseActionPress || msg.Button != tea.MouseButtonLeft {
+			return nil, false
+		}
+		tl.drag = tl.findSplitter(msg.X, msg.Y)
+		return nil, tl.drag != nil
+	}
+	switch msg.Action {
+	case tea.MouseActionMotion:
+		return tl.drag.move(msg.X, msg.Y), true
+	case tea.MouseActionRelease:
+		tl.drag = nil
+	}
+	return nil, true
+}
+
+// Find the splitter at the position in this layout or any nested layout.
+// The splitter of two adjacent tiles is the last cell of the first tile, the splitter
+// of two tiles separated by a gap (or margins) is made of the cells between them.
+// Splitters next to tiles with a fixed size along the layout direction are not resizable.
+func (tl *TileLayout) findSplitter(x, y int) *splitterDrag {
+	if !tl.Rect.Contains(x, y) {
+		return nil
+	}
+	if tl.Splitters {
+		horizontal := tl.Direction == Horizontal
+		pos := y
+		if horizontal {
+			pos = x
+		}
+		for i := 0; i+1 < len(tl.Tiles); i++ {
+			a, b := tl.Tiles[i], tl.Tiles[i+1]
+			if a == nil || b == nil || isFixed(a, horizontal) || isFixed(b, horizontal) {
+				continue
+			}
+			aStart, aLen := along(a.GetRect(), horizontal)
+			bStart, _ := along(b.GetRect(), horizontal)
+			last := aStart + aLen - 1
+			if pos == last && bStart == last+1 || pos > last && pos < bStart {
+				return &splitterDrag{layout: tl, index: i, offset: pos - last}
+			}
+		}
+	}
+	for _, tile := range tl.Tiles {
+		if layout, ok := tile.(*TileLayout); ok {
+			if drag := layout.findSplitter(x, y); drag != nil {
+				return drag
+			}
+		}
+	}
+	return nil
+}
+
+// Move the splitter to the position. The two tiles are resized so that the last cell of the
+// first tile is at the position (less the offset of the press), within their min/max. Their weights are changed accordingly,
+// keeping the sum of the two weights, so the other tiles are not affected.
+func (d *splitterDrag) move(x, y int) tea.Cmd {
+	tl := d.layout
+	horizontal := tl.Direction == Horizontal
+	a, b := tl.Tiles[d.index], tl.Tiles[d.index+1]
+	aStart, aLen := along(a.GetRect(), horizontal)
+	_, bLen := along(b.GetRect(), horizontal)
+	pos := y
+	if horizontal {
+		pos = x
+	}
+	total := aLen + bLen
+	// both tiles must stay within their min/max
+	aSpan, bSpan := axisSpan(a.GetSize(), horizontal), axisSpan(b.GetSize(), horizontal)
+	lower, upper := aSpan.min, total-bSpan.min
+	if aSpan.max > 0 {
+		upper = min(upper, aSpan.max)
+	}
+	if bSpan.max > 0 {
+		lower = max(lower, total-bSpan.max)
+	}
+	newLen := min(max(pos-d.offset-aStart+1, lower), upper)
+	aSize, bSize := a.GetSize(), b.GetSize()
+	weights := aSize.Weight + bSize.Weight
+	if total == 0 || weights <= 0 || newLen == aLen {
+		return nil
+	}
+	aSize.Weight = weights * float64(newLen) / float64(total)
+	bSize.Weight = weights - aSize.Weight
+	a.SetSize(aSize)
+	b.SetSize(bSize)
+
+	moved := func() tea.Msg {
+		return SplitterMovedMsg{
+			Layout:  tl.Name,
+			Tiles:   [2]string{a.GetName(), b.GetName()},
+			Weights: [2]float64{aSize.Weight, bSize.Weight},
+		}
+	}
+	return tea.Batch(moved, notifyChanged(tl.relayout()))
+}
+
+// Returns true if the tile has a fixed size along the width (horizontal) or the height.
+// Hidden tiles are fixed at no size.
+func isFixed(t Tile, horizontal bool) bool {
+	if t.GetSize().Hidden {
+		return true
+	}
+	if horizontal {
+		return t.GetSize().FixedWidth > 0
+	}
+	return t.GetSize().FixedHeight > 0
+}
+
+// The start and the length of the rect along the width (horizontal) or the height.
+func along(r Rect, horizontal bool) (int, int) {
+	if horizontal {
+		return r.X, r.Width
+	}
+	return r.Y, r.Height
+}
@@ -0,0 +1,52 @@
+package tilelayout
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestFindSplitter(t *testing.T) {
+	tests := []struct {
+		name string
+		gap  int
+		hits map[int]bool
+	}{
+		// a is 0-4, b is 5-9
+		{"adjacent tiles", 0, map[int]bool{3: false, 4: true, 5: false}},
+		// a is 0-3, the gap is 4-5, b is 6-9
+		{"gap", 2, map[int]bool{3: false, 4: true, 5: true, 6: false}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := NewRoot(Horizontal)
+			root.Splitters = true
+			root.Gap = tt.gap
+			root.Add(newTestTile("a", Size{Weight: 0.5}))
+			root.Add(newTestTile("b", Size{Weight: 0.5}))
+			root.Update(tea.WindowSizeMsg{Width: 10, Height: 1})
+			for x, hit := range tt.hits {
+				if got := root.findSplitter(x, 0) != nil; got != hit {
+					t.Errorf("findSplitter(%d) found %v; want %v", x, got, hit)
+				}
+			}
+		})
+	}
+}
+
+func TestSplitterFollowsPointer(t *testing.T) {
+	root := NewRoot(Horizontal)
+	root.Splitters = true
+	root.Gap = 2
+	a := newTestTile("a", Size{Weight: 0.5})
+	root.Add(a)
+	root.Add(newTestTile("b", Size{Weight: 0.5}))
+	root.Update(tea.WindowSizeMsg{Width: 10, Height: 1})
+	// press on the second cell of the gap and move by two cells
+	root.Update(tea.MouseMsg{X: 5, Action: tea.MouseActionPress, Button: tea.MouseButtonLeft})
+	root.Update(tea.MouseMsg{X: 7, Action: tea.MouseActionMotion, Button: tea.MouseButtonLeft})
+	root.Update(tea.MouseMsg{X: 7, Action: tea.MouseActionRelease, Button: tea.MouseButtonLeft})
+	if width := a.GetRect().Width; width != 6 {
+		t.Errorf("a is %d wide; want 6", width)
+	}
+}
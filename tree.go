@@ -0,0 +1,63 @@
+package tilelayout
+
+import tea "github.com/charmbracelet/bubbletea"
+
+// Returns the root of the tree the layout is in.
+func (tl *TileLayout) root() *TileLayout {
+	root := tl
+	for {
+		parent, ok := root.GetParent().(*TileLayout)
+		if !ok {
+			return root
+		}
+		root = parent
+	}
+}
+
+// Returns the layouts from this one down to the parent of the target tile,
+// or nil if the tile is not in the tree. A tile wrapped by Bordered is found by its wrapper.
+// The tiles are matched by their IDs, see sameTile.
+func (tl *TileLayout) pathTo(target Tile) []*TileLayout {
+	for _, tile := range tl.Tiles {
+		if tile == nil {
+			continue
+		}
+		if sameTile(tile, target) {
+			return []*TileLayout{tl}
+		}
+		if layout, ok := tile.(*TileLayout); ok {
+			if path := layout.pathTo(target); path != nil {
+				return append([]*TileLayout{tl}, path...)
+			}
+		}
+	}
+	return nil
+}
+
+// Update a single tile anywhere in the tree with the message, matched by its ID (see sameTile).
+// Returns false if the tile is not in the tree.
+func (tl *TileLayout) updateTile(target Tile, msg tea.Msg) (tea.Cmd, bool) {
+	for i, tile := range tl.Tiles {
+		if tile == nil {
+			continue
+		}
+		if sameTile(tile, target) {
+			updated, cmd := tile.Update(msg)
+			tl.Tiles[i] = updated.(Tile)
+			return cmd, true
+		}
+		if layout, ok := tile.(*TileLayout); ok {
+			if cmd, ok := layout.updateTile(target, msg); ok {
+				return cmd, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// Returns true if the tiles are the same tile. The tiles are compared by their IDs,
+// as the value tiles may not be comparable and their updates return copies.
+// A BorderedTile has the ID of the tile it wraps. Tiles without an ID are never the same.
+func sameTile(a, b Tile) bool {
+	return a != nil && b != nil && a.GetID() != 0 && a.GetID() == b.GetID()
+}
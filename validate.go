@@ -0,0 +1,193 @@
+package tilelayout
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Kinds of invalid constraints, to be checked with errors.Is.
+var (
+	ErrNegative           = errors.New("constraint is negative")
+	ErrWeightOutOfRange   = errors.New("weight is out of the range 0.0 - 1.0")
+	ErrMinExceedsMax      = errors.New("min exceeds max")
+	ErrFixedOutOfRange    = errors.New("fixed is outside of min/max")
+	ErrFixedWithWeight    = errors.New("fixed is combined with weight")
+	ErrWeightsExceedOne   = errors.New("weights of the tiles sum up to more than 1.0")
+	ErrFixedExceedsLayout = errors.New("fixed tiles are larger than the layout")
+)
+
+// Error describing an invalid constraint. Path is the path to the tile, made of the
+// tile names separated by "/", e.g. "Root/ContentArea/Box3". It is empty for Size.Validate.
+type ConstraintError struct {
+	Path  string
+	Field string
+	Err   error
+}
+
+func (e *ConstraintError) Error() string {
+	if e.Path == "" {
+		return fmt.Sprintf("%s: %v", e.Field, e.Err)
+	}
+	return fmt.Sprintf("%s: %s: %v", e.Path, e.Field, e.Err)
+}
+
+func (e *ConstraintError) Unwrap() error { return e.Err }
+
+// Validate the constraints of the size. Returns the joined ConstraintErrors or nil.
+// Checks that need to know the direction of the layout (like a fixed width combined
+// with a weight in a horizontal layout) are done by TileLayout.Validate.
+func (s Size) Validate() error {
+	return errors.Join(s.validate("")...)
+}
+
+func (s Size) validate(path string) []error {
+	var errs []error
+	invalid := func(field string, err error) {
+		errs = append(errs, &ConstraintError{Path: path, Field: field, Err: err})
+	}
+	for _, c := range []struct {
+		field string
+		value int
+	}{
+		{"MinWidth", s.MinWidth}, {"MinHeight", s.MinHeight},
+		{"MaxWidth", s.MaxWidth}, {"MaxHeight", s.MaxHeight},
+		{"FixedWidth", s.FixedWidth}, {"FixedHeight", s.FixedHeight},
+		{"Margin.Top", s.Margin.Top}, {"Margin.Right", s.Margin.Right},
+		{"Margin.Bottom", s.Margin.Bottom}, {"Margin.Left", s.Margin.Left},
+	} {
+		if c.value < 0 {
+			invalid(c.field, ErrNegative)
+		}
+	}
+	if s.Weight < 0 || s.Weight > 1 {
+		invalid("Weight", ErrWeightOutOfRange)
+	}
+	if s.MaxWidth > 0 && s.MinWidth > s.MaxWidth {
+		invalid("MinWidth", ErrMinExceedsMax)
+	}
+	if s.MaxHeight > 0 && s.MinHeight > s.MaxHeight {
+		invalid("MinHeight", ErrMinExceedsMax)
+	}
+	if s.FixedWidth > 0 && (s.FixedWidth < s.MinWidth || s.MaxWidth > 0 && s.FixedWidth > s.MaxWidth) {
+		invalid("FixedWidth", ErrFixedOutOfRange)
+	}
+	if s.FixedHeight > 0 && (s.FixedHeight < s.MinHeight || s.MaxHeight > 0 && s.FixedHeight > s.MaxHeight) {
+		invalid("FixedHeight", ErrFixedOutOfRange)
+	}
+	// with both dimensions fixed, the weight can never have an effect
+	if s.FixedWidth > 0 && s.FixedHeight > 0 && s.Weight > 0 {
+		invalid("Weight", ErrFixedWithWeight)
+	}
+	return errs
+}
+
+// Validate the layout tree. Returns the joined ConstraintErrors of the layout and all nested
+// tiles or nil. Besides Size.Validate, for every layout it is checked that:
+//   - tiles fixed along the direction of the layout have no weight
+//   - the weights of the tiles sum up to at most 1.0
+//   - the fixed tiles (with the padding, the gaps and the margins) fit in the layout,
+//     if the layout has a fixed or max size
+//   - the names of the tiles are unique in the layout
+//
+// Can be used before the first WindowSizeMsg to reject broken layouts.
+func (tl *TileLayout) Validate() error {
+	return errors.Join(tl.validate(tl.Name)...)
+}
+
+func (tl *TileLayout) validate(path string) []error {
+	errs := tl.Size.validate(path)
+	invalid := func(path, field string, err error) {
+		errs = append(errs, &ConstraintError{Path: path, Field: field, Err: err})
+	}
+	for _, c := range []struct {
+		field string
+		value int
+	}{
+		{"Gap", tl.Gap},
+		{"Padding.Top", tl.Padding.Top}, {"Padding.Right", tl.Padding.Right},
+		{"Padding.Bottom", tl.Padding.Bottom}, {"Padding.Left", tl.Padding.Left},
+	} {
+		if c.value < 0 {
+			invalid(path, c.field, ErrNegative)
+		}
+	}
+	horizontal := tl.Direction == Horizontal
+	mainBound := layoutBound(tl.Size, horizontal)
+	crossBound := layoutBound(tl.Size, !horizontal)
+	sumWeight := 0.0
+	// the fixed tiles share the layout with the padding, the frame, the gaps and the margins
+	before, after := tl.inset().along(horizontal)
+	sumFixed := before + after
+	visible := 0
+	names := make(map[string]bool)
+	for _, tile := range tl.Tiles {
+		if tile == nil {
+			continue
+		}
+		childPath := path + "/" + tile.GetName()
+		if names[tile.GetName()] {
+			invalid(childPath, "Name", ErrDuplicateName)
+		}
+		names[tile.GetName()] = true
+		if layout, ok := tile.(*TileLayout); ok {
+			errs = append(errs, layout.validate(childPath)...)
+		} else {
+			errs = append(errs, tile.GetSize().validate(childPath)...)
+		}
+		size := tile.GetSize()
+		if size.Hidden {
+			// takes no space in the layout
+			continue
+		}
+		if visible > 0 {
+			sumFixed += tl.gap()
+		}
+		visible++
+		before, after := size.Margin.along(horizontal)
+		sumFixed += before + after
+		mainFixed, crossFixed := size.FixedHeight, size.FixedWidth
+		mainField, crossField := "FixedHeight", "FixedWidth"
+		if horizontal {
+			mainFixed, crossFixed = size.FixedWidth, size.FixedHeight
+			mainField, crossField = "FixedWidth", "FixedHeight"
+		}
+		if mainFixed > 0 {
+			sumFixed += mainFixed
+			if size.Weight > 0 {
+				invalid(childPath, mainField, ErrFixedWithWeight)
+			}
+		} else {
+			sumWeight += size.Weight
+		}
+		if crossBound > 0 && crossFixed > crossBound {
+			invalid(childPath, crossField, ErrFixedExceedsLayout)
+		}
+	}
+	// allow for rounding, like 3 x 0.33
+	if sumWeight > 1+1e-6 {
+		invalid(path, "Weight", ErrWeightsExceedOne)
+	}
+	if mainBound > 0 && sumFixed > mainBound {
+		field := "Height"
+		if horizontal {
+			field = "Width"
+		}
+		invalid(path, field, ErrFixedExceedsLayout)
+	}
+	return errs
+}
+
+// The known upper bound of a layout size along the width (horizontal) or the height:
+// the fixed size, the max size, or 0 if the layout is not constrained.
+func layoutBound(s Size, horizontal bool) int {
+	if horizontal {
+		if s.FixedWidth > 0 {
+			return s.FixedWidth
+		}
+		return s.MaxWidth
+	}
+	if s.FixedHeight > 0 {
+		return s.FixedHeight
+	}
+	return s.MaxHeight
+}
@@ -0,0 +1,114 @@
+package tilelayout
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name  string
+		tiles []Tile
+		err   error
+		path  string
+		field string
+	}{
+		{
+			name:  "min exceeds max",
+			tiles: []Tile{newTestTile("a", Size{MinWidth: 5, MaxWidth: 3, Weight: 1})},
+			err:   ErrMinExceedsMax,
+			path:  "Root/a",
+			field: "MinWidth",
+		},
+		{
+			name:  "fixed outside of min/max",
+			tiles: []Tile{newTestTile("a", Size{FixedHeight: 10, MaxHeight: 5, Weight: 1})},
+			err:   ErrFixedOutOfRange,
+			path:  "Root/a",
+			field: "FixedHeight",
+		},
+		{
+			name:  "fixed with weight along the direction",
+			tiles: []Tile{newTestTile("a", Size{FixedWidth: 3, Weight: 0.5})},
+			err:   ErrFixedWithWeight,
+			path:  "Root/a",
+			field: "FixedWidth",
+		},
+		{
+			name: "weights exceed one",
+			tiles: []Tile{
+				newTestTile("a", Size{Weight: 0.6}),
+				newTestTile("b", Size{Weight: 0.6}),
+			},
+			err:   ErrWeightsExceedOne,
+			path:  "Root",
+			field: "Weight",
+		},
+		{
+			name: "fixed tiles exceed the layout",
+			tiles: []Tile{func() Tile {
+				box := NewTileLayout("box", Horizontal, Size{FixedWidth: 10})
+				box.Tiles = []Tile{
+					newTestTile("a", Size{FixedWidth: 6}),
+					newTestTile("b", Size{FixedWidth: 6}),
+				}
+				return box
+			}()},
+			err:   ErrFixedExceedsLayout,
+			path:  "Root/box",
+			field: "Width",
+		},
+		{
+			name: "duplicate names",
+			tiles: []Tile{
+				newTestTile("a", Size{Weight: 0.5}),
+				newTestTile("a", Size{Weight: 0.5}),
+			},
+			err:   ErrDuplicateName,
+			path:  "Root/a",
+			field: "Name",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := NewRoot(Horizontal)
+			// set directly, as Add rejects the duplicate names
+			root.Tiles = tt.tiles
+			err := root.Validate()
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("Validate() = %v; want %v", err, tt.err)
+			}
+			var ce *ConstraintError
+			if !errors.As(err, &ce) {
+				t.Fatalf("Validate() = %v; want a *ConstraintError", err)
+			}
+			if ce.Path != tt.path || ce.Field != tt.field {
+				t.Errorf("ConstraintError at %q, %q; want %q, %q", ce.Path, ce.Field, tt.path, tt.field)
+			}
+		})
+	}
+}
+
+func TestValidateValid(t *testing.T) {
+	root := NewRoot(Horizontal)
+	root.Add(newTestTile("a", Size{FixedWidth: 3}))
+	root.Add(newTestTile("b", Size{Weight: 0.33}))
+	root.Add(newTestTile("c", Size{Weight: 0.33}))
+	root.Add(newTestTile("d", Size{Weight: 0.33, MinWidth: 2, MaxWidth: 8}))
+	if err := root.Validate(); err != nil {
+		t.Errorf("Validate() = %v; want nil", err)
+	}
+}
+
+func TestSizeValidate(t *testing.T) {
+	err := Size{MinHeight: 4, MaxHeight: 2, Margin: Spacing{Left: -1}}.Validate()
+	for _, want := range []error{ErrMinExceedsMax, ErrNegative} {
+		if !errors.Is(err, want) {
+			t.Errorf("Validate() = %v; want %v", err, want)
+		}
+	}
+	var ce *ConstraintError
+	if errors.As(err, &ce) && ce.Path != "" {
+		t.Errorf("ConstraintError path = %q; want none", ce.Path)
+	}
+}